@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+// allocationMapWidth is the number of characters in the --map bar
+const allocationMapWidth = 64
+
+// handleMap prints a bar showing which parts of the first CIDR, the parent, are taken by the
+// remaining CIDRs
+func handleMap(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("--map needs a parent CIDR followed by at least one allocated CIDR")
+	}
+
+	networks := make([]*ipv4.Network, 0, len(args))
+
+	for _, arg := range args {
+		network, err := ipv4.ParseCIDR(arg)
+		if err != nil {
+			return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", arg, err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	parent, allocated := networks[0], networks[1:]
+
+	output, err := parent.AllocationMap(allocated, allocationMapWidth)
+	if err != nil {
+		return fmt.Errorf("parent.AllocationMap: %w", err)
+	}
+
+	fmt.Println(output)
+
+	return nil
+}
+
+// handleRemaining prints how many --remaining sized subnets of the first CIDR, the parent, are
+// still free of the allocated CIDRs that follow it and those in --file
+func handleRemaining(args []string, opts options) error {
+	if len(args) < 1 {
+		printUsage()
+		return fmt.Errorf("no CIDR argument provided")
+	}
+
+	parent, err := ipv4.ParseCIDR(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", args[0], err)
+	}
+
+	var allocated []*ipv4.Network
+
+	err = eachInput(args[1:], opts.file, func(line batchLine) error {
+		network, err := ipv4.ParseCIDR(line.cidr)
+		if err != nil {
+			return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", line.cidr, err)
+		}
+
+		allocated = append(allocated, network)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	count, err := parent.RemainingSubnets(opts.remaining, allocated)
+	if err != nil {
+		return fmt.Errorf("--remaining: %w", err)
+	}
+
+	fmt.Printf(" Remaining:\t%d /%d subnets free in %s\n", count, opts.remaining, parent)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMapFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--map", "10.0.0.0/24", "10.0.0.0/26", "10.0.0.64/26"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := strings.Repeat("#", 32) + strings.Repeat(" ", 32) + "\n50.0% allocated, 50.0% free\n"
+	if output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	for _, args := range [][]string{{"10.0.0.0/24"}, {"10.0.0.0/24", "2001:db8::/64"}} {
+		if err := runWithArgs(append([]string{"ripcalc", "--map"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestRemainingFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allocated.txt")
+
+	if err := os.WriteFile(path, []byte("# taken\nweb: 10.0.0.0/26\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--remaining", "26", "--file", path, "10.0.0.0/24"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := " Remaining:\t3 /26 subnets free in 10.0.0.0/24\n"
+	if output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	for _, args := range [][]string{
+		{"--remaining", "23", "10.0.0.0/24"},
+		{"--remaining", "26"},
+		{"--remaining", "26", "10.0.0.0/24", "2001:db8::/64"},
+	} {
+		if err := runWithArgs(append([]string{"ripcalc"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
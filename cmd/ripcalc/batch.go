@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// batchLine is one input line of batch mode, with the label that preceded the CIDR if any
+type batchLine struct {
+	number int
+	label  string
+	cidr   string
+}
+
+// parseBatchLine splits a line into an optional label and the CIDR, which is always the last
+// whitespace-separated token. Blank lines and lines starting with # are skipped.
+func parseBatchLine(line string) (label, cidr string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+
+	i := strings.LastIndexAny(line, " \t")
+	if i < 0 {
+		return "", normalizeInput(line), true
+	}
+
+	return strings.TrimSpace(line[:i]), normalizeInput(line[i+1:]), true
+}
+
+// openBatchInput opens path for reading, treating - as standard input
+func openBatchInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+
+	return f, nil
+}
+
+// eachBatchLine calls fn for every CIDR line of the file at path as it's read, so large inputs
+// stream rather than being buffered. Errors from fn are annotated with the line number.
+func eachBatchLine(path string, fn func(line batchLine) error) (err error) {
+	input, err := openBatchInput(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if closeErr := input.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("input.Close: %w", closeErr)
+		}
+	}()
+
+	scanner := bufio.NewScanner(input)
+	number := 0
+
+	for scanner.Scan() {
+		number++
+
+		label, cidr, ok := parseBatchLine(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		if err := fn(batchLine{number: number, label: label, cidr: cidr}); err != nil {
+			return fmt.Errorf("%s line %d: %w", path, number, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// eachInput calls fn for every CIDR argument and then for every line of the --file input, if any
+func eachInput(args []string, file string, fn func(line batchLine) error) error {
+	for _, arg := range args {
+		if err := fn(batchLine{cidr: arg}); err != nil {
+			return err
+		}
+	}
+
+	if file == "" {
+		return nil
+	}
+
+	return eachBatchLine(file, fn)
+}
+
+// batchTotal accumulates the networks in a batch and their usable hosts for --total, in a big.Int
+// so IPv4 and IPv6 inputs can be mixed
+type batchTotal struct {
+	networks int
+	hosts    big.Int
+}
+
+// add counts the input's usable hosts, splitting an IPv4 dash range into its CIDRs
+func (t *batchTotal) add(cidr string, opts options) error {
+	if isDashRange(cidr) {
+		start, end, err := ipv4.ParseDashRange(cidr)
+		if err != nil {
+			return fmt.Errorf("ipv4.ParseDashRange: %w", err)
+		}
+
+		networks, err := ipv4.RangeToCIDRs(start, end)
+		if err != nil {
+			return fmt.Errorf("ipv4.RangeToCIDRs: %w", err)
+		}
+
+		for _, network := range networks {
+			t.networks++
+			t.hosts.Add(&t.hosts, new(big.Int).SetUint64(uint64(network.HostCount)))
+		}
+
+		return nil
+	}
+
+	if opts.isIPv6(cidr) {
+		network, err := ipv6.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("ipv6.ParseCIDR: %w", err)
+		}
+
+		if err := network.Calculate(); err != nil {
+			return fmt.Errorf("network.Calculate: %w", err)
+		}
+
+		t.networks++
+		t.hosts.Add(&t.hosts, network.HostCount)
+
+		return nil
+	}
+
+	network, err := ipv4.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("ipv4.ParseCIDR: %w", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		return fmt.Errorf("network.Calculate: %w", err)
+	}
+
+	t.networks++
+	t.hosts.Add(&t.hosts, new(big.Int).SetUint64(uint64(network.HostCount)))
+
+	return nil
+}
+
+func handleBatch(path string, opts options) error {
+	if opts.jobs > 1 {
+		return handleBatchParallel(path, opts)
+	}
+
+	first := true
+
+	var total batchTotal
+
+	err := eachBatchLine(path, func(line batchLine) error {
+		if !first {
+			fmt.Println()
+		}
+
+		first = false
+
+		if line.label != "" {
+			fmt.Printf("%s:\n", line.label)
+		}
+
+		if err := handleInput(line.cidr, opts); err != nil {
+			return err
+		}
+
+		if opts.total {
+			return total.add(line.cidr, opts)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.total {
+		fmt.Printf("\n  Networks:\t%d\n     Total:\t%s usable hosts\n", total.networks, total.hosts.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseBatchLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantLabel string
+		wantCIDR  string
+		wantOK    bool
+	}{
+		{"labelled", "webservers 10.0.1.0/24", "webservers", "10.0.1.0/24", true},
+		{"unlabelled", "10.0.1.0/24", "", "10.0.1.0/24", true},
+		{"multi-word label", "db primary\t2001:db8::/64", "db primary", "2001:db8::/64", true},
+		{"surrounding whitespace", "  web   10.0.0.0/8  ", "web", "10.0.0.0/8", true},
+		{"bracketed IPv6", "db [2001:db8::]/64", "db", "2001:db8::/64", true},
+		{"blank", "   ", "", "", false},
+		{"comment", "# inventory", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, cidr, ok := parseBatchLine(tt.line)
+			if label != tt.wantLabel || cidr != tt.wantCIDR || ok != tt.wantOK {
+				t.Errorf("parseBatchLine(%q) = %q, %q, %v, want %q, %q, %v",
+					tt.line, label, cidr, ok, tt.wantLabel, tt.wantCIDR, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFileFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.txt")
+	content := "# inventory\nwebservers 10.0.1.0/24\n\n2001:db8::/64\n"
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--no-binary", "--file", path})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.HasPrefix(output, "webservers:\n") {
+		t.Errorf("Output should start with the label, got:\n%s", output)
+	}
+
+	for _, element := range []string{"10.0.1.0/24", "2001:db8::/64"} {
+		if !strings.Contains(output, element) {
+			t.Errorf("Output missing %q\nFull output:\n%s", element, output)
+		}
+	}
+
+	if !strings.Contains(output, "\n\n   Address:\t2001:db8::") {
+		t.Errorf("The unlabelled line should have no label header\nFull output:\n%s", output)
+	}
+}
+
+func TestFileFlagTotal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.txt")
+
+	if err := os.WriteFile(path, []byte("10.0.0.0/24\nlab 10.0.1.0/24\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		wantIt   bool
+	}{
+		{"with --total", []string{"--total", "--file", path}, "  Networks:\t2\n     Total:\t508 usable hosts\n", true},
+		{"without --total", []string{"--file", path}, "Total:", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs(append([]string{"ripcalc"}, tt.args...))
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if strings.Contains(output, tt.expected) != tt.wantIt {
+				t.Errorf("Output contains %q = %v, want %v\nFull output:\n%s", tt.expected, !tt.wantIt, tt.wantIt, output)
+			}
+		})
+	}
+}
+
+func TestFileFlagReportsLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.txt")
+
+	if err := os.WriteFile(path, []byte("10.0.0.0/24\nbad not-a-cidr\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	var err error
+	captureStdout(t, func() {
+		err = runWithArgs([]string{"ripcalc", "--file", path})
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error naming line 2, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// handleDelta prints the network the input becomes when its prefix is lengthened or shortened by
+// --delta, with a note on how the size changes, followed by the usual output for it
+func handleDelta(cidr string, opts options) error {
+	prefix, err := parseDeltaPrefix(cidr, opts)
+	if err != nil {
+		return err
+	}
+
+	bits := prefix.Bits() + opts.delta
+	if bits < 0 || bits > prefix.Addr().BitLen() {
+		return fmt.Errorf("invalid --delta %+d: /%d is outside /0 to /%d", opts.delta, bits, prefix.Addr().BitLen())
+	}
+
+	adjusted := netip.PrefixFrom(prefix.Addr(), bits).Masked()
+
+	fmt.Printf("     Delta:\t/%d -> /%d\n", prefix.Bits(), bits)
+	fmt.Printf("    Effect:\t%s\n\n", deltaEffect(opts.delta))
+
+	opts.delta = 0
+
+	return handleInput(adjusted.String(), opts)
+}
+
+// parseDeltaPrefix parses the input with the family's own parser, so shorthand such as
+// 192.168/16 is accepted as it is elsewhere
+func parseDeltaPrefix(cidr string, opts options) (netip.Prefix, error) {
+	if opts.isIPv6(cidr) {
+		network, err := ipv6.ParseCIDR(cidr)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("ipv6.ParseCIDR: %w", err)
+		}
+
+		return network.Prefix(), nil
+	}
+
+	network, err := ipv4.ParseCIDR(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("ipv4.ParseCIDR: %w", err)
+	}
+
+	return network.Prefix(), nil
+}
+
+// deltaEffect describes the change in size for a prefix adjustment, e.g. "half the hosts, splits
+// into 2 subnets" for +1
+func deltaEffect(delta int) string {
+	switch {
+	case delta == 1:
+		return "half the hosts, splits into 2 subnets"
+	case delta == -1:
+		return "twice the hosts, covers 2 of the original subnets"
+	case delta > 0:
+		factor := new(big.Int).Lsh(big.NewInt(1), uint(delta))
+		return fmt.Sprintf("1/%s of the hosts, splits into %s subnets", factor, factor)
+	default:
+		factor := new(big.Int).Lsh(big.NewInt(1), uint(-delta))
+		return fmt.Sprintf("%s times the hosts, covers %s of the original subnets", factor, factor)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeltaFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name: "lengthen by one",
+			args: []string{"--delta", "+1", "10.0.0.0/24"},
+			expected: []string{
+				"/24 -> /25",
+				"half the hosts, splits into 2 subnets",
+				"10.0.0.0/25",
+				"Broadcast:\t10.0.0.127",
+			},
+		},
+		{
+			name: "shorten by one",
+			args: []string{"--delta", "-1", "10.0.1.0/24"},
+			expected: []string{
+				"/24 -> /23",
+				"twice the hosts, covers 2 of the original subnets",
+				"10.0.0.0/23",
+				"Broadcast:\t10.0.1.255",
+			},
+		},
+		{
+			name:     "IPv6 lengthen by four",
+			args:     []string{"--delta", "4", "2001:db8::/48"},
+			expected: []string{"/48 -> /52", "1/16 of the hosts, splits into 16 subnets", "2001:db8::/52"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs(append([]string{"ripcalc"}, tt.args...))
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			for _, element := range tt.expected {
+				if !strings.Contains(output, element) {
+					t.Errorf("Output missing expected element: %q\nFull output:\n%s", element, output)
+				}
+			}
+		})
+	}
+}
+
+func TestDeltaFlagOutOfRange(t *testing.T) {
+	tests := [][]string{
+		{"--delta", "+1", "10.0.0.1/32"},
+		{"--delta", "-9", "10.0.0.0/8"},
+		{"--delta", "+1", "::1/128"},
+	}
+
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			err := runWithArgs(append([]string{"ripcalc"}, args...))
+			if err == nil || !strings.Contains(err.Error(), "invalid --delta") {
+				t.Errorf("runWithArgs() error = %v, want invalid --delta", err)
+			}
+		})
+	}
+}
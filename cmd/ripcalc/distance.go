@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// handleDistance prints the signed number of addresses from the first network's base address to
+// the second's
+func handleDistance(args []string, opts options) error {
+	if len(args) != 2 {
+		return fmt.Errorf("--distance needs exactly two CIDRs, got %d", len(args))
+	}
+
+	if opts.isIPv6(args[0]) != opts.isIPv6(args[1]) {
+		return fmt.Errorf("cannot measure the distance between IPv4 and IPv6 networks")
+	}
+
+	if opts.isIPv6(args[0]) {
+		a, err := ipv6.ParseCIDR(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid IPv6 CIDR notation %q: %w", args[0], err)
+		}
+
+		b, err := ipv6.ParseCIDR(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid IPv6 CIDR notation %q: %w", args[1], err)
+		}
+
+		fmt.Printf("  Distance:\t%s addresses\n", ipv6.Distance(a, b))
+
+		return nil
+	}
+
+	a, err := ipv4.ParseCIDR(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", args[0], err)
+	}
+
+	b, err := ipv4.ParseCIDR(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", args[1], err)
+	}
+
+	fmt.Printf("  Distance:\t%d addresses\n", ipv4.Distance(a, b))
+
+	return nil
+}
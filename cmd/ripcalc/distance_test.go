@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDistanceFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"10.0.0.0/24", "10.0.4.0/24"}, "  Distance:\t1024 addresses\n"},
+		{[]string{"10.0.4.0/24", "10.0.0.0/24"}, "  Distance:\t-1024 addresses\n"},
+		{[]string{"2001:db8::/64", "2001:db8:0:1::/64"}, "  Distance:\t18446744073709551616 addresses\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs(append([]string{"ripcalc", "--distance"}, tt.args...))
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDistanceFlagErrors(t *testing.T) {
+	tests := [][]string{
+		{"10.0.0.0/24"},
+		{"10.0.0.0/24", "2001:db8::/64"},
+		{"10.0.0.0/24", "10.0.0.0/33"},
+	}
+
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			if err := runWithArgs(append([]string{"ripcalc", "--distance"}, args...)); err == nil {
+				t.Errorf("runWithArgs() should fail for %v", args)
+			}
+		})
+	}
+}
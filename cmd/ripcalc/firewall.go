@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// Set names used for the generated nftables sets and ipset hashes
+const (
+	firewallSetIPv4 = "ripcalc_v4"
+	firewallSetIPv6 = "ripcalc_v6"
+)
+
+// firewallEntries holds the networks of a batch split by family
+type firewallEntries struct {
+	ipv4 []*ipv4.Network
+	ipv6 []*ipv6.Network
+}
+
+// add parses cidr and records its network, so inputs with host bits set load cleanly
+func (e *firewallEntries) add(cidr string, opts options) error {
+	if opts.isIPv6(cidr) {
+		network, err := ipv6.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("invalid IPv6 CIDR notation %q: %w", cidr, err)
+		}
+
+		network.Format.NoClass = true
+		if err := network.Calculate(); err != nil {
+			return fmt.Errorf("failed to calculate IPv6 network: %w", err)
+		}
+
+		e.ipv6 = append(e.ipv6, network)
+
+		return nil
+	}
+
+	network, err := ipv4.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", cidr, err)
+	}
+
+	network.Format.NoClass = true
+	if err := network.Calculate(); err != nil {
+		return fmt.Errorf("failed to calculate IPv4 network: %w", err)
+	}
+
+	e.ipv4 = append(e.ipv4, network)
+
+	return nil
+}
+
+// elements returns the entries of each family as network CIDRs, aggregated so overlapping and
+// adjacent inputs collapse and interval sets load without conflicting elements
+func (e *firewallEntries) elements() (v4, v6 []string, err error) {
+	v4Networks, err := ipv4.Aggregate(e.ipv4)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ipv4.Aggregate: %w", err)
+	}
+
+	for _, network := range v4Networks {
+		v4 = append(v4, fmt.Sprintf("%s/%d", network.Network, network.PrefixLength))
+	}
+
+	v6Networks, err := ipv6.Aggregate(e.ipv6)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ipv6.Aggregate: %w", err)
+	}
+
+	for _, network := range v6Networks {
+		v6 = append(v6, network.CanonicalCIDR())
+	}
+
+	return v4, v6, nil
+}
+
+// nftSets returns an nftables set block per family holding the entries, ready to paste into a
+// table definition
+func (e *firewallEntries) nftSets() (string, error) {
+	v4, v6, err := e.elements()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	writeSet := func(name, typ string, entries []string) {
+		if len(entries) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&b, "set %s {\n", name)
+		fmt.Fprintf(&b, "\ttype %s\n", typ)
+		b.WriteString("\tflags interval\n")
+		fmt.Fprintf(&b, "\telements = { %s }\n", strings.Join(entries, ", "))
+		b.WriteString("}\n")
+	}
+
+	writeSet(firewallSetIPv4, "ipv4_addr", v4)
+	writeSet(firewallSetIPv6, "ipv6_addr", v6)
+
+	return b.String(), nil
+}
+
+// ipsetScript returns an ipset restore script creating a hash:net set per family and adding the
+// entries, suitable for `ipset restore`
+func (e *firewallEntries) ipsetScript() (string, error) {
+	v4, v6, err := e.elements()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	writeSet := func(name, family string, entries []string) {
+		if len(entries) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&b, "create %s hash:net family %s -exist\n", name, family)
+		for _, entry := range entries {
+			fmt.Fprintf(&b, "add %s %s -exist\n", name, entry)
+		}
+	}
+
+	writeSet(firewallSetIPv4, "inet", v4)
+	writeSet(firewallSetIPv6, "inet6", v6)
+
+	return b.String(), nil
+}
+
+// handleFirewallSet collects every CIDR argument and --file line into a single nftables set block
+// or ipset script
+func handleFirewallSet(args []string, opts options) error {
+	var entries firewallEntries
+
+	err := eachInput(args, opts.file, func(line batchLine) error {
+		return entries.add(line.cidr, opts)
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(entries.ipv4) == 0 && len(entries.ipv6) == 0 {
+		printUsage()
+		return fmt.Errorf("no CIDR argument provided")
+	}
+
+	script := entries.ipsetScript
+	if opts.nft {
+		script = entries.nftSets
+	}
+
+	output, err := script()
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(output)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+func TestNftFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--nft", "10.0.0.0/24", "192.168.1.7/16", "2001:db8::/32"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "set ripcalc_v4 {\n" +
+		"\ttype ipv4_addr\n" +
+		"\tflags interval\n" +
+		"\telements = { 10.0.0.0/24, 192.168.0.0/16 }\n" +
+		"}\n" +
+		"set ripcalc_v6 {\n" +
+		"\ttype ipv6_addr\n" +
+		"\tflags interval\n" +
+		"\telements = { 2001:db8::/32 }\n" +
+		"}\n"
+
+	if output != expected {
+		t.Errorf("Output = %q, expected %q", output, expected)
+	}
+}
+
+func TestNftFlagOverlappingInputs(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--nft", "10.0.0.0/24", "10.0.0.0/25", "10.0.1.0/24",
+			"2001:db8:1::/48", "2001:db8::/32"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "set ripcalc_v4 {\n" +
+		"\ttype ipv4_addr\n" +
+		"\tflags interval\n" +
+		"\telements = { 10.0.0.0/23 }\n" +
+		"}\n" +
+		"set ripcalc_v6 {\n" +
+		"\ttype ipv6_addr\n" +
+		"\tflags interval\n" +
+		"\telements = { 2001:db8::/32 }\n" +
+		"}\n"
+
+	if output != expected {
+		t.Errorf("Output = %q, expected %q", output, expected)
+	}
+}
+
+func TestIpsetFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--ipset", "10.0.0.0/24", "10.1.0.0/16"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "create ripcalc_v4 hash:net family inet -exist\n" +
+		"add ripcalc_v4 10.0.0.0/24 -exist\n" +
+		"add ripcalc_v4 10.1.0.0/16 -exist\n"
+
+	if output != expected {
+		t.Errorf("Output = %q, expected %q", output, expected)
+	}
+}
+
+func TestNftFlagInvalidInput(t *testing.T) {
+	var err error
+	captureStdout(t, func() {
+		err = runWithArgs([]string{"ripcalc", "--nft", "10.0.0.0/24", "not-a-cidr"})
+	})
+
+	if err == nil {
+		t.Error("Expected error for invalid CIDR")
+	}
+}
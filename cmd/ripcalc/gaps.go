@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+// handleGaps prints the CIDRs of address space left uncovered between the lowest and highest of the
+// CIDR arguments and --file lines, one per line. It prints nothing when they tile the space.
+func handleGaps(args []string, opts options) error {
+	var networks []*ipv4.Network
+
+	err := eachInput(args, opts.file, func(line batchLine) error {
+		network, err := ipv4.ParseCIDR(line.cidr)
+		if err != nil {
+			return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", line.cidr, err)
+		}
+
+		networks = append(networks, network)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(networks) == 0 {
+		return fmt.Errorf("no CIDR list provided, pass CIDRs or --file")
+	}
+
+	gaps, err := ipv4.CoverageGaps(networks)
+	if err != nil {
+		return fmt.Errorf("failed to find gaps: %w", err)
+	}
+
+	for _, gap := range gaps {
+		fmt.Println(gap)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGapsFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocks.txt")
+	if err := os.WriteFile(path, []byte("# plan\nweb 10.0.0.0/25\ndb 10.0.1.0/24\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"file", []string{"--file", path}, "10.0.0.128/25\n"},
+		{"arguments", []string{"10.0.2.0/24", "10.0.0.0/24"}, "10.0.1.0/24\n"},
+		{"contiguous", []string{"10.0.0.0/25", "10.0.0.128/25"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs(append([]string{"ripcalc", "--gaps"}, tt.args...)); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+
+	for _, args := range [][]string{{}, {"10.0.0.0/24", "2001:db8::/64"}} {
+		if err := runWithArgs(append([]string{"ripcalc", "--gaps"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
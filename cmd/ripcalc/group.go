@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// ipv4Entry and ipv6Entry keep a grouped network with the input line it came from, so its label
+// and original notation survive sorting
+type ipv4Entry struct {
+	network *ipv4.Network
+	line    batchLine
+}
+
+type ipv6Entry struct {
+	network *ipv6.Network
+	line    batchLine
+}
+
+// groupedJSON is the --group-family --json document, with both arrays always present
+type groupedJSON struct {
+	IPv4 []*ipv4.Network `json:"ipv4"`
+	IPv6 []*ipv6.Network `json:"ipv6"`
+}
+
+// groupByFamily calculates every CIDR argument and --file line, splits them by family and sorts
+// each family with its Compare function
+func groupByFamily(args []string, opts options) ([]ipv4Entry, []ipv6Entry, error) {
+	var v4s []ipv4Entry
+
+	var v6s []ipv6Entry
+
+	err := eachInput(args, opts.file, func(line batchLine) error {
+		if isDashRange(line.cidr) {
+			return fmt.Errorf("--group-family needs CIDRs, not the range %q", line.cidr)
+		}
+
+		network, err := calculateNetwork(line.cidr, opts)
+		if err != nil {
+			return err
+		}
+
+		switch network := network.(type) {
+		case *ipv4.Network:
+			v4s = append(v4s, ipv4Entry{network: network, line: line})
+		case *ipv6.Network:
+			v6s = append(v6s, ipv6Entry{network: network, line: line})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slices.SortStableFunc(v4s, func(a, b ipv4Entry) int { return ipv4.Compare(a.network, b.network) })
+	slices.SortStableFunc(v6s, func(a, b ipv6Entry) int { return ipv6.Compare(a.network, b.network) })
+
+	return v4s, v6s, nil
+}
+
+// handleGroupFamily prints a batch with every IPv4 result first and then every IPv6 one, each
+// sorted by address, under [IPv4] and [IPv6] headings. With --json it prints one object with
+// "ipv4" and "ipv6" arrays instead.
+func handleGroupFamily(args []string, opts options) error {
+	if len(args) == 0 && opts.file == "" {
+		printUsage()
+		return fmt.Errorf("no CIDR argument provided")
+	}
+
+	v4s, v6s, err := groupByFamily(args, opts)
+	if err != nil {
+		return err
+	}
+
+	var v4Lines, v6Lines []batchLine
+
+	document := groupedJSON{IPv4: []*ipv4.Network{}, IPv6: []*ipv6.Network{}}
+
+	for _, entry := range v4s {
+		v4Lines = append(v4Lines, entry.line)
+		document.IPv4 = append(document.IPv4, entry.network)
+	}
+
+	for _, entry := range v6s {
+		v6Lines = append(v6Lines, entry.line)
+		document.IPv6 = append(document.IPv6, entry.network)
+	}
+
+	if opts.json {
+		output, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return fmt.Errorf("json.MarshalIndent: %w", err)
+		}
+
+		fmt.Println(string(output))
+
+		return nil
+	}
+
+	first := true
+
+	for _, section := range []struct {
+		heading string
+		lines   []batchLine
+	}{
+		{"[IPv4]", v4Lines},
+		{"[IPv6]", v6Lines},
+	} {
+		if len(section.lines) == 0 {
+			continue
+		}
+
+		if !first {
+			fmt.Println()
+		}
+
+		first = false
+
+		fmt.Println(section.heading)
+
+		for _, line := range section.lines {
+			fmt.Println()
+
+			if line.label != "" {
+				fmt.Printf("%s:\n", line.label)
+			}
+
+			if err := handleInput(line.cidr, opts); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
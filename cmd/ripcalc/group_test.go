@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestGroupFamilyFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mixed.txt")
+	if err := os.WriteFile(path, []byte("2001:db8:1::/48\n10.1.0.0/16\nweb 10.0.0.0/24\n2001:db8::/48\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--group-family", "--network-only", "--file", path}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "[IPv4]\n\nweb:\n10.0.0.0\n\n10.1.0.0\n\n[IPv6]\n\n2001:db8::\n\n2001:db8:1::\n"
+	if output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	output = captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--group-family", "--json", "--file", path}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	var document map[string][]struct {
+		Address string `json:"address"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &document); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v\n%s", err, output)
+	}
+
+	want := map[string][]string{
+		"ipv4": {"10.0.0.0", "10.1.0.0"},
+		"ipv6": {"2001:db8::", "2001:db8:1::"},
+	}
+
+	for family, addresses := range want {
+		var got []string
+		for _, network := range document[family] {
+			got = append(got, network.Address)
+		}
+
+		if !slices.Equal(got, addresses) {
+			t.Errorf("%s addresses = %v, want %v", family, got, addresses)
+		}
+	}
+
+	if err := runWithArgs([]string{"ripcalc", "--group-family", "10.0.0.5-10.0.0.20"}); err == nil {
+		t.Error("--group-family should fail for a dash range")
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hclNetwork is implemented by *ipv4.Network and *ipv6.Network
+type hclNetwork interface {
+	HCLText() string
+}
+
+// handleHCL prints several CIDR arguments, or any --file, as one HCL list of objects, e.g. for a
+// Terraform locals block. A --file line's label becomes a label attribute on its object.
+func handleHCL(args []string, opts options) error {
+	var objects []string
+
+	err := eachInput(args, opts.file, func(line batchLine) error {
+		network, err := calculateNetwork(line.cidr, opts)
+		if err != nil {
+			return err
+		}
+
+		hcl, ok := network.(hclNetwork)
+		if !ok {
+			return fmt.Errorf("%q has no HCL form", line.cidr)
+		}
+
+		object := hcl.HCLText()
+		if line.label != "" {
+			object = "{ label = " + strconv.Quote(line.label) + ", " + strings.TrimPrefix(object, "{ ")
+		}
+
+		objects = append(objects, "  "+object)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(objects) == 0 {
+		fmt.Println("[]")
+		return nil
+	}
+
+	fmt.Printf("[\n%s\n]\n", strings.Join(objects, ",\n"))
+
+	return nil
+}
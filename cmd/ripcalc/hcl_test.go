@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHCLFlagSeveralArguments(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--hcl", "10.0.0.0/24", "10.1.0.0/24"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "[\n" +
+		`  { cidr = "10.0.0.0/24", netmask = "255.255.255.0", network = "10.0.0.0", broadcast = "10.0.0.255", hosts = 254 },` + "\n" +
+		`  { cidr = "10.1.0.0/24", netmask = "255.255.255.0", network = "10.1.0.0", broadcast = "10.1.0.255", hosts = 254 }` + "\n" +
+		"]\n"
+	if output != expected {
+		t.Errorf("Output =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestHCLFlagFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.txt")
+
+	if err := os.WriteFile(path, []byte("# subnets\nweb 10.0.0.0/24\n2001:db8::/64\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--hcl", "--file", path}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "[\n" +
+		`  { label = "web", cidr = "10.0.0.0/24", netmask = "255.255.255.0", network = "10.0.0.0", broadcast = "10.0.0.255", hosts = 254 },` + "\n" +
+		`  { cidr = "2001:db8::/64", netmask = "ffff:ffff:ffff:ffff::", network = "2001:db8::", host_min = "2001:db8::", host_max = "2001:db8::ffff:ffff:ffff:ffff", hosts = 18446744073709551616 }` + "\n" +
+		"]\n"
+	if output != expected {
+		t.Errorf("Output =\n%s\nwant\n%s", output, expected)
+	}
+}
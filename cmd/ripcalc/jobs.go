@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ronny/ripcalc/internal/layout"
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// parallelFlags are the flags a --jobs batch honours. Workers render each line's usual output as
+// text, so flags that print something else would be silently lost and are rejected instead.
+var parallelFlags = map[string]bool{
+	"file":        true,
+	"jobs":        true,
+	"total":       true,
+	"family":      true,
+	"no-binary":   true,
+	"plain":       true,
+	"no-class":    true,
+	"binary-wrap": true,
+	"ipv6-mask":   true,
+	"ipv6-binary": true,
+	"ipv6-mixed":  true,
+	"rir":         true,
+	"count-human": true,
+	"ranges-file": true,
+	"tabsize":     true,
+	"spaces":      true,
+}
+
+// parallelChunkLines is how many lines each worker gets per chunk, bounding how much of a huge
+// batch is buffered while keeping the workers busy
+const parallelChunkLines = 256
+
+// batchResult is the rendered output, or the error, for one line of a --jobs batch
+type batchResult struct {
+	text string
+	err  error
+}
+
+// renderInput returns the usual output for a CIDR or range as text, the same as handleInput
+// prints with only the display flags set, so it can run on any goroutine
+func renderInput(cidr string, opts options) (string, error) {
+	var text string
+
+	switch {
+	case isDashRange(cidr):
+		start, end, err := ipv4.ParseDashRange(cidr)
+		if err != nil {
+			return "", fmt.Errorf("invalid IPv4 range %q: %w", cidr, err)
+		}
+
+		networks, err := ipv4.RangeToCIDRs(start, end)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert range to CIDRs: %w", err)
+		}
+
+		lines := make([]string, 0, len(networks))
+		for _, network := range networks {
+			lines = append(lines, network.String())
+		}
+
+		return strings.Join(lines, "\n"), nil
+	case opts.isIPv6(cidr):
+		network, err := ipv6.ParseCIDR(cidr)
+		if err != nil {
+			return "", fmt.Errorf("invalid IPv6 CIDR notation %q: %w", cidr, err)
+		}
+
+		applyIPv6Format(network, opts)
+
+		if err := network.Calculate(); err != nil {
+			return "", fmt.Errorf("failed to calculate IPv6 network: %w", err)
+		}
+
+		text = ipv6Text(network, opts)
+	default:
+		network, err := ipv4.ParseCIDR(cidr)
+		if err != nil {
+			return "", fmt.Errorf("invalid IPv4 CIDR notation %q: %w", cidr, err)
+		}
+
+		applyIPv4Format(network, opts)
+
+		if err := network.Calculate(); err != nil {
+			return "", fmt.Errorf("failed to calculate IPv4 network: %w", err)
+		}
+
+		text = ipv4Text(network, opts)
+	}
+
+	if opts.tabSize > 0 {
+		text = layout.ExpandTabs(text, opts.tabSize)
+	}
+
+	return text, nil
+}
+
+// renderParallel renders every line with opts.jobs workers, writing each result to the slot of
+// its line so the output keeps the input order however the work is scheduled
+func renderParallel(lines []batchLine, opts options) []batchResult {
+	results := make([]batchResult, len(lines))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range opts.jobs {
+		wg.Go(func() {
+			for i := range indexes {
+				text, err := renderInput(lines[i].cidr, opts)
+				results[i] = batchResult{text: text, err: err}
+			}
+		})
+	}
+
+	for i := range lines {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// handleBatchParallel is handleBatch spread over --jobs workers. Lines are read in chunks,
+// rendered in parallel and printed in their original order, stopping at the first line that
+// fails as a serial batch would.
+func handleBatchParallel(path string, opts options) error {
+	first := true
+
+	var total batchTotal
+
+	chunk := make([]batchLine, 0, parallelChunkLines*opts.jobs)
+
+	flush := func() error {
+		for i, result := range renderParallel(chunk, opts) {
+			line := chunk[i]
+
+			if !first {
+				fmt.Println()
+			}
+
+			first = false
+
+			if line.label != "" {
+				fmt.Printf("%s:\n", line.label)
+			}
+
+			if result.err != nil {
+				return fmt.Errorf("%s line %d: %w", path, line.number, result.err)
+			}
+
+			fmt.Println(result.text)
+
+			if opts.total {
+				if err := total.add(line.cidr, opts); err != nil {
+					return fmt.Errorf("%s line %d: %w", path, line.number, err)
+				}
+			}
+		}
+
+		chunk = chunk[:0]
+
+		return nil
+	}
+
+	// A failed flush is returned as is rather than through eachBatchLine, which would prefix it with
+	// the number of the line that filled the chunk instead of the one that failed
+	var flushErr error
+
+	err := eachBatchLine(path, func(line batchLine) error {
+		chunk = append(chunk, line)
+		if len(chunk) < cap(chunk) {
+			return nil
+		}
+
+		flushErr = flush()
+
+		return flushErr
+	})
+	if flushErr != nil {
+		return flushErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if opts.total {
+		fmt.Printf("\n  Networks:\t%d\n     Total:\t%s usable hosts\n", total.networks, total.hosts.String())
+	}
+
+	return nil
+}
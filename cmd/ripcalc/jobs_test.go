@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// writeBatchFile writes a --file batch of count lines mixing labelled, IPv4, IPv6 and range
+// inputs, so a reordered result would be easy to spot
+func writeBatchFile(tb testing.TB, count int) string {
+	tb.Helper()
+
+	var b strings.Builder
+
+	for i := range count {
+		switch i % 4 {
+		case 0:
+			fmt.Fprintf(&b, "line%d 10.%d.%d.0/%d\n", i, i/256%256, i%256, 16+i%17)
+		case 1:
+			fmt.Fprintf(&b, "2001:db8:%x::/%d\n", i, 48+i%80)
+		case 2:
+			fmt.Fprintf(&b, "192.168.%d.%d-%d\n", i%256, i%100, 100+i%100)
+		default:
+			fmt.Fprintf(&b, "172.16.%d.%d/%d\n", i%256, i%256, i%33)
+		}
+	}
+
+	path := filepath.Join(tb.TempDir(), "batch.txt")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		tb.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	return path
+}
+
+func TestJobsFlagPreservesOrder(t *testing.T) {
+	// More lines than one chunk, so the order across chunks is checked too
+	path := writeBatchFile(t, 3*parallelChunkLines*4+7)
+
+	for _, flags := range [][]string{{"--total"}, {"--no-binary", "--no-class"}, {"--plain"}} {
+		t.Run(strings.Join(flags, " "), func(t *testing.T) {
+			run := func(jobs int) string {
+				return captureStdout(t, func() {
+					args := append([]string{"ripcalc", "--jobs", strconv.Itoa(jobs), "--file", path}, flags...)
+					if err := runWithArgs(args); err != nil {
+						t.Fatalf("run() failed: %v", err)
+					}
+				})
+			}
+
+			serial, parallel := run(1), run(4)
+			if serial != parallel {
+				t.Errorf("--jobs 4 output differs from --jobs 1 (%d vs %d bytes)", len(parallel), len(serial))
+			}
+		})
+	}
+}
+
+func TestJobsFlagErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.txt")
+	if err := os.WriteFile(path, []byte("10.0.0.0/24\n\n10.0.0.0/33\n10.0.1.0/24\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	var err error
+
+	captureStdout(t, func() {
+		err = runWithArgs([]string{"ripcalc", "--jobs", "2", "--file", path})
+	})
+
+	if err == nil || !strings.Contains(err.Error(), path+" line 3:") {
+		t.Errorf("runWithArgs() error = %v, want it to name line 3", err)
+	}
+
+	for _, args := range [][]string{
+		{"--jobs", "0", "--file", path},
+		{"--jobs", "2", "--gateways", "--file", path},
+	} {
+		if err := runWithArgs(append([]string{"ripcalc"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	path := writeBatchFile(b, 20000)
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatalf("os.OpenFile() error = %v", err)
+	}
+
+	b.Cleanup(func() {
+		if err := devNull.Close(); err != nil {
+			b.Errorf("devNull.Close() error = %v", err)
+		}
+	})
+
+	originalStdout := os.Stdout
+	os.Stdout = devNull
+
+	b.Cleanup(func() { os.Stdout = originalStdout })
+
+	for _, jobs := range []int{1, max(runtime.GOMAXPROCS(0), 2)} {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			for b.Loop() {
+				if err := runWithArgs([]string{"ripcalc", "--jobs", strconv.Itoa(jobs), "--file", path}); err != nil {
+					b.Fatalf("runWithArgs() error = %v", err)
+				}
+			}
+		})
+	}
+}
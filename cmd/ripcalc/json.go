@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// calculateNetwork parses and calculates cidr in its family, ready for JSON marshalling, applying
+// --strict-network
+func calculateNetwork(cidr string, opts options) (json.Marshaler, error) {
+	if opts.isIPv6(cidr) {
+		network, err := ipv6.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IPv6 CIDR notation %q: %w", cidr, err)
+		}
+
+		network.Format.NoClass = opts.noClass
+		network.CustomRanges = opts.ranges.ipv6
+
+		if err := network.Calculate(); err != nil {
+			return nil, fmt.Errorf("failed to calculate IPv6 network: %w", err)
+		}
+
+		if err := checkStrictNetwork(network, opts); err != nil {
+			return nil, err
+		}
+
+		return network, nil
+	}
+
+	network, err := ipv4.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IPv4 CIDR notation %q: %w", cidr, err)
+	}
+
+	network.Format.NoClass = opts.noClass
+	network.CustomRanges = opts.ranges.ipv4
+
+	if err := network.Calculate(); err != nil {
+		return nil, fmt.Errorf("failed to calculate IPv4 network: %w", err)
+	}
+
+	if err := checkStrictNetwork(network, opts); err != nil {
+		return nil, err
+	}
+
+	return network, nil
+}
+
+// handleJSON prints every input as JSON. With --ndjson each result is written as its own line as
+// soon as it's calculated; otherwise a single input prints one indented object and several print
+// an indented array.
+func handleJSON(args []string, opts options) error {
+	if len(args) == 0 && opts.file == "" {
+		printUsage()
+		return fmt.Errorf("no CIDR argument provided")
+	}
+
+	if opts.ndjson {
+		encoder := json.NewEncoder(os.Stdout)
+
+		return eachInput(args, opts.file, func(line batchLine) error {
+			network, err := calculateNetwork(line.cidr, opts)
+			if err != nil {
+				return err
+			}
+
+			if err := encoder.Encode(network); err != nil {
+				return fmt.Errorf("encoder.Encode: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	if opts.jsonMap {
+		return printJSONMap(args, opts)
+	}
+
+	// An empty --file still prints an array, not null
+	results := []json.Marshaler{}
+
+	err := eachInput(args, opts.file, func(line batchLine) error {
+		network, err := calculateNetwork(line.cidr, opts)
+		if err != nil {
+			return err
+		}
+
+		results = append(results, network)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var value any = results
+	if len(args) == 1 && opts.file == "" {
+		value = results[0]
+	}
+
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+
+	fmt.Println(string(output))
+
+	return nil
+}
+
+// jsonError is the --json-map entry for an input that couldn't be calculated
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+// printJSONMap prints every input as one indented JSON object keyed by the input as written, so
+// consumers can look a result up directly. Inputs that fail to parse or calculate are kept as an
+// object with an "error" field rather than stopping the batch.
+func printJSONMap(args []string, opts options) error {
+	results := make(map[string]any)
+	inputs, strictFailures := 0, 0
+
+	err := eachInput(args, opts.file, func(line batchLine) error {
+		inputs++
+
+		network, err := calculateNetwork(line.cidr, opts)
+		if err != nil {
+			if errors.Is(err, errStrictNetwork) {
+				strictFailures++
+			}
+
+			results[line.cidr] = jsonError{Error: err.Error()}
+
+			return nil
+		}
+
+		results[line.cidr] = network
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+
+	fmt.Println(string(output))
+
+	// The map still lists every input, but a lint gate must see the failure in the exit status
+	if strictFailures > 0 {
+		return fmt.Errorf("%w: host bits set in %d of %d inputs", errStrictNetwork, strictFailures, inputs)
+	}
+
+	return nil
+}
+
+// decodeNetwork decodes one document written by --json, choosing the family from its $schema, and
+// returns the network's CIDR and whether the document left out the class and type
+func decodeNetwork(data json.RawMessage) (cidr string, noClass bool, err error) {
+	var header struct {
+		Schema string `json:"$schema"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return "", false, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	switch header.Schema {
+	case ipv4.SchemaURL:
+		var network ipv4.Network
+		if err := json.Unmarshal(data, &network); err != nil {
+			return "", false, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+
+		return network.String(), network.Format.NoClass, nil
+	case ipv6.SchemaURL:
+		var network ipv6.Network
+		if err := json.Unmarshal(data, &network); err != nil {
+			return "", false, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+
+		return network.String(), network.Format.NoClass, nil
+	default:
+		return "", false, fmt.Errorf("unknown $schema %q, expected ripcalc --json output", header.Schema)
+	}
+}
+
+// handleFromJSON reads ripcalc's own --json or --ndjson output from --file, or stdin without it,
+// and renders every network again with the other flags, so one stage of a pipeline can pass
+// structured data on and a later one print it. Arrays, single objects and streams of objects are
+// all accepted.
+func handleFromJSON(opts options) (err error) {
+	path := opts.file
+	if path == "" {
+		path = "-"
+	}
+
+	input, err := openBatchInput(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if closeErr := input.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("input.Close: %w", closeErr)
+		}
+	}()
+
+	decoder := json.NewDecoder(input)
+	first := true
+
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("decoder.Decode: %w", err)
+		}
+
+		documents := []json.RawMessage{value}
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+			if err := json.Unmarshal(value, &documents); err != nil {
+				return fmt.Errorf("json.Unmarshal: %w", err)
+			}
+		}
+
+		for _, document := range documents {
+			cidr, noClass, err := decodeNetwork(document)
+			if err != nil {
+				return err
+			}
+
+			if !first {
+				fmt.Println()
+			}
+
+			first = false
+
+			renderOpts := opts
+			renderOpts.noClass = opts.noClass || noClass
+
+			if err := handleInput(cidr, renderOpts); err != nil {
+				return err
+			}
+		}
+	}
+}
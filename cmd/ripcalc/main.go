@@ -1,19 +1,32 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/ronny/ripcalc/internal/layout"
 	"github.com/ronny/ripcalc/ipv4"
 	"github.com/ronny/ripcalc/ipv6"
 )
 
+// errCheckFailed is returned by the predicate flags such as --is-doc when the check doesn't hold.
+// It only sets the exit status, so main doesn't print it.
+var errCheckFailed = errors.New("check failed")
+
+// errStrictNetwork marks inputs --strict-network rejects for having host bits set
+var errStrictNetwork = errors.New("--strict-network")
+
 func main() {
 	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		if !errors.Is(err, errCheckFailed) {
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		}
+
 		os.Exit(1)
 	}
 }
@@ -22,15 +35,167 @@ func run() error {
 	return runWithArgs(os.Args)
 }
 
+// options holds the parsed command-line flags
+type options struct {
+	help        bool
+	ipv6Mask    bool
+	ipv6Binary  bool
+	countHuman  bool
+	noBinary    bool
+	zone        string
+	reference   string
+	gateways    bool
+	noClass     bool
+	hcl         bool
+	ipv6Mixed   bool
+	rir         bool
+	exclude     string
+	file        string
+	hosts       bool
+	order       string
+	record      string
+	nft         bool
+	ipset       bool
+	reverse     bool
+	json        bool
+	ndjson      bool
+	jsonMap     bool
+	isDoc       bool
+	isGlobal    bool
+	plain       bool
+	binaryWrap  int
+	rangesFile  string
+	family      string
+	magic       bool
+	delta       int
+	total       bool
+	sweep       string
+	sweepFrom   int
+	sweepTo     int
+	covers      string
+	eui64       string
+	distance    bool
+	proto       bool
+	advice      bool
+	networkOnly bool
+	bothCounts  bool
+	allocMap    bool
+	in          string
+	notIn       string
+	fingerprint bool
+	parent      string
+	sipcalc     bool
+	provision   int
+	validate    bool
+	list64      bool
+	limit       int
+	gaps        bool
+	tabSize     int
+	spaces      bool
+	rollUp      bool
+	binaryOnly  bool
+	strictNet   bool
+	anatomy     bool
+	groupFamily bool
+	preview     int
+	fromJSON    bool
+	ospf        bool
+	eigrp       bool
+	area        int
+	remaining   int
+	url         bool
+	port        int
+	forHosts    int
+	template    string
+	split       int
+	csv         bool
+	octetValues bool
+	jobs        int
+	ranges      customRanges
+}
+
 func runWithArgs(args []string) error {
 	// Create a new FlagSet to avoid global flag conflicts in tests
 	fs := flag.NewFlagSet("ripcalc", flag.ContinueOnError)
-	
+
 	// Define flags
-	var showMask = fs.Bool("ipv6-mask", false, "Show netmask and wildcard for IPv6 (always shown for IPv4)")
-	var showBinary = fs.Bool("ipv6-binary", false, "Show binary representation for IPv6 (always shown for IPv4)")
-	var help = fs.Bool("help", false, "Show help message")
-	fs.BoolVar(help, "h", false, "Show help message (shorthand)")
+	var opts options
+
+	fs.StringVar(&opts.family, "family", "", "Force the input to be read as ipv4 or ipv6 instead of detecting it")
+	fs.BoolVar(&opts.ipv6Mask, "ipv6-mask", false, "Show netmask and wildcard for IPv6 (always shown for IPv4)")
+	fs.BoolVar(&opts.ipv6Binary, "ipv6-binary", false, "Show binary representation for IPv6 (always shown for IPv4)")
+	fs.BoolVar(&opts.ipv6Mixed, "ipv6-mixed", false, "Show IPv4-embedded IPv6 addresses with a dotted-quad tail")
+	fs.BoolVar(&opts.advice, "advice", false, "Print IPv6 planning advice for the prefix length after the result")
+	fs.BoolVar(&opts.rir, "rir", false, "Show the regional registry responsible for IPv6 global unicast addresses")
+	fs.BoolVar(&opts.countHuman, "count-human", false, "Show large IPv6 host counts in word-scale units as well")
+	fs.BoolVar(&opts.plain, "plain", false, "Align the output with spaces instead of tabs and hide binary, for pasting into chat")
+	fs.IntVar(&opts.tabSize, "tabsize", 0, "Expand tabs in the output to spaces with tab stops every N columns")
+	fs.BoolVar(&opts.spaces, "spaces", false, "Expand tabs in the output to spaces with 8-column tab stops")
+	fs.IntVar(&opts.binaryWrap, "binary-wrap", 0, "Break the binary representation onto a new line every N groups")
+	fs.BoolVar(&opts.binaryOnly, "binary-only", false, "Print only the address in binary, with a space at the network/host boundary")
+	fs.BoolVar(&opts.octetValues, "octet-values", false, "Also print each octet (or hextet) of the address in binary under its place values")
+	fs.BoolVar(&opts.noBinary, "no-binary", false, "Hide binary representation for IPv4")
+	fs.StringVar(&opts.zone, "zone", "", "Print a reverse DNS zone file template with PTR records under the given domain")
+	fs.BoolVar(&opts.reverse, "reverse", false, "Print the nibble-aligned ip6.arpa zone for delegating an IPv6 network")
+	fs.StringVar(&opts.eui64, "eui64", "", "Print the SLAAC address the given MAC autoconfigures in an IPv6 /64")
+	fs.BoolVar(&opts.ospf, "ospf", false, "Print the OSPF network statement (OSPFv3 area range for IPv6)")
+	fs.BoolVar(&opts.eigrp, "eigrp", false, "Print the EIGRP network statement for an IPv4 network")
+	fs.IntVar(&opts.area, "area", 0, "OSPF area for --ospf")
+	fs.BoolVar(&opts.url, "url", false, "Print the IPv6 address bracketed for a URL, e.g. [2001:db8::1]:443")
+	fs.IntVar(&opts.port, "port", 0, "Port appended by --url")
+	fs.StringVar(&opts.record, "record", "", "Print a forward DNS A or AAAA record for the address under the given name")
+	fs.StringVar(&opts.reference, "reference", "", "Print a subnetting reference table for a family (ipv4 or ipv6)")
+	fs.BoolVar(&opts.anatomy, "anatomy", false, "Also print how the prefix splits into network and host bits, with the address counts")
+	fs.BoolVar(&opts.bothCounts, "both-counts", false, "Also print the usable host count and the total address count on separate lines")
+	fs.BoolVar(&opts.fingerprint, "fingerprint", false, "Print a short stable hash of the canonical network for change detection")
+	fs.StringVar(&opts.parent, "parent", "", "Also print the subnet's position among its same-size siblings within the given IPv4 CIDR")
+	fs.BoolVar(&opts.rollUp, "rollup", false, "Print the IPv4 supernet one bit shorter and the sibling that would complete it")
+	fs.BoolVar(&opts.strictNet, "strict-network", false, "Reject inputs whose address has host bits set below the prefix, e.g. 10.0.0.5/24")
+	fs.BoolVar(&opts.networkOnly, "network-only", false, "Print only the network (base) address, without the prefix length")
+	fs.BoolVar(&opts.gateways, "gateways", false, "Print the suggested gateway (first usable) and secondary (last usable) addresses")
+	fs.StringVar(&opts.rangesFile, "ranges-file", "", "Classify addresses using custom \"cidr label\" ranges from a file first")
+	fs.BoolVar(&opts.noClass, "no-class", false, "Omit the address class and type from the output")
+	fs.BoolVar(&opts.json, "json", false, "Print the result as JSON (an array when there are several inputs)")
+	fs.BoolVar(&opts.ndjson, "ndjson", false, "Print one JSON object per line per input, streaming as each is calculated")
+	fs.BoolVar(&opts.fromJSON, "from-json", false, "Read ripcalc --json output from stdin (or --file) and print it again with the other flags")
+	fs.BoolVar(&opts.groupFamily, "group-family", false, "Print IPv4 results first and then IPv6, each sorted by address (with --json, as one object)")
+	fs.BoolVar(&opts.jsonMap, "json-map", false, "Print one JSON object keyed by each input, with an error entry for invalid ones")
+	fs.BoolVar(&opts.proto, "proto", false, "Write each result as a length-prefixed binary message (see the wire package)")
+	fs.BoolVar(&opts.sipcalc, "sipcalc", false, "Print the result with sipcalc's field labels and layout, for scripts that parse sipcalc")
+	fs.BoolVar(&opts.hcl, "hcl", false, "Print the result as a Terraform/HCL object")
+	fs.BoolVar(&opts.nft, "nft", false, "Print all inputs as nftables set blocks")
+	fs.BoolVar(&opts.ipset, "ipset", false, "Print all inputs as an ipset restore script")
+	fs.StringVar(&opts.in, "in", "", "Exit 0 if the address is inside any listed CIDR, 1 otherwise")
+	fs.StringVar(&opts.notIn, "not-in", "", "Exit 0 if the address is outside every listed CIDR, 1 otherwise")
+	fs.BoolVar(&opts.isGlobal, "is-global", false, "Exit 0 if the address is routable on the public internet, 1 otherwise")
+	fs.BoolVar(&opts.validate, "validate", false, "Print nothing and exit 0 if every input is a valid CIDR, otherwise print the error and exit 1")
+	fs.BoolVar(&opts.isDoc, "is-doc", false, "Exit 0 if the address is reserved for documentation, 1 otherwise")
+	fs.StringVar(&opts.exclude, "exclude", "", "Print the IPv4 CIDRs left after removing the given subnet from the network")
+	fs.BoolVar(&opts.gaps, "gaps", false, "Print the IPv4 space left uncovered between the lowest and highest input CIDRs")
+	fs.BoolVar(&opts.allocMap, "map", false, "Draw how much of the first IPv4 CIDR is taken by the CIDRs that follow it")
+	fs.BoolVar(&opts.distance, "distance", false, "Print the number of addresses between the base addresses of two CIDRs")
+	fs.IntVar(&opts.delta, "delta", 0, "Show the network with its prefix lengthened (+N) or shortened (-N) by N bits")
+	fs.StringVar(&opts.template, "template", "", "Render each result with a Go text/template, e.g. '{{.Network}}/{{.Prefix}}'")
+	fs.IntVar(&opts.forHosts, "for-hosts", 0, "Calculate the smallest IPv4 network starting at the address that fits N usable hosts")
+	fs.IntVar(&opts.remaining, "remaining", -1, "Print how many /N subnets of the first IPv4 CIDR are still free of the allocated CIDRs")
+	fs.IntVar(&opts.split, "split", -1, "Print each /N subnet of the IPv4 network, one per line")
+	fs.BoolVar(&opts.csv, "csv", false, "Print the --split subnets as CSV with their addresses and host counts")
+	fs.IntVar(&opts.provision, "provision", -1, "Print each /N subnet of the IPv4 network with its gateway and broadcast address")
+	fs.BoolVar(&opts.magic, "magic", false, "Print the subnetting magic number and the octet it applies to")
+	fs.BoolVar(&opts.list64, "list64", false, "List the /64 LANs within an IPv6 network, up to --limit of them")
+	fs.IntVar(&opts.limit, "limit", 256, "Most /64s --list64 prints")
+	fs.IntVar(&opts.preview, "preview", 0, "Also list the first N and last N addresses of an IPv6 network")
+	fs.BoolVar(&opts.hosts, "hosts", false, "List every usable IPv4 host address, one per line")
+	fs.StringVar(&opts.order, "order", "asc", "Order of the --hosts listing (asc or desc)")
+	fs.StringVar(&opts.file, "file", "", "Read optionally labelled CIDRs, one per line, from a file (- for stdin)")
+	fs.StringVar(&opts.sweep, "sweep", "", "Print the calculation for the address at every prefix length from --from to --to")
+	fs.IntVar(&opts.sweepFrom, "from", 0, "First prefix length of a --sweep")
+	fs.IntVar(&opts.sweepTo, "to", -1, "Last prefix length of a --sweep (default the longest for the family)")
+	fs.StringVar(&opts.covers, "covers", "", "Print the network containing the address at every prefix length, from /0 down")
+	fs.IntVar(&opts.jobs, "jobs", 1, "Calculate --file lines on N workers in parallel, keeping the output in order")
+	fs.BoolVar(&opts.total, "total", false, "After a --file batch, print the number of networks and their total usable hosts")
+	fs.BoolVar(&opts.help, "help", false, "Show help message")
+	fs.BoolVar(&opts.help, "h", false, "Show help message (shorthand)")
 
 	// Custom usage function
 	fs.Usage = func() {
@@ -44,32 +209,214 @@ func runWithArgs(args []string) error {
 	}
 
 	// Handle help requests
-	if *help {
+	if opts.help {
 		printUsage()
 		return nil
 	}
 
+	if opts.reference != "" {
+		return printReference(opts.reference)
+	}
+
+	if opts.order != "asc" && opts.order != "desc" {
+		return fmt.Errorf("unknown order %q, expected asc or desc", opts.order)
+	}
+
+	if opts.family != "" && opts.family != "ipv4" && opts.family != "ipv6" {
+		return fmt.Errorf("unknown family %q, expected ipv4 or ipv6", opts.family)
+	}
+
+	if opts.tabSize < 0 {
+		return fmt.Errorf("invalid --tabsize %d, expected a positive number of columns", opts.tabSize)
+	}
+
+	if opts.spaces && opts.tabSize == 0 {
+		opts.tabSize = 8
+	}
+
+	if opts.jobs < 1 {
+		return fmt.Errorf("invalid --jobs %d, expected at least 1 worker", opts.jobs)
+	}
+
+	if opts.jobs > 1 {
+		var unsupported string
+
+		fs.Visit(func(f *flag.Flag) {
+			if !parallelFlags[f.Name] && unsupported == "" {
+				unsupported = f.Name
+			}
+		})
+
+		if unsupported != "" {
+			return fmt.Errorf("--jobs can't be combined with --%s, it only parallelises the usual --file output", unsupported)
+		}
+	}
+
+	if opts.preview < 0 {
+		return fmt.Errorf("invalid --preview %d, expected a positive number of addresses", opts.preview)
+	}
+
+	if opts.binaryWrap < 0 {
+		return fmt.Errorf("invalid --binary-wrap %d, expected a positive number of groups", opts.binaryWrap)
+	}
+
+	if opts.rangesFile != "" {
+		opts.ranges, err = loadRangesFile(opts.rangesFile)
+		if err != nil {
+			return fmt.Errorf("failed to load ranges file: %w", err)
+		}
+	}
+
+	if opts.csv && opts.split == -1 {
+		return fmt.Errorf("--csv needs --split")
+	}
+
+	if opts.in != "" && opts.notIn != "" {
+		return fmt.Errorf("--in and --not-in can't be used together")
+	}
+
+	inputs := make([]string, len(fs.Args()))
+	for i, arg := range fs.Args() {
+		inputs[i] = normalizeInput(arg)
+	}
+
+	if opts.forHosts != 0 {
+		for i, input := range inputs {
+			if inputs[i], err = prefixForHosts(input, opts.forHosts); err != nil {
+				return fmt.Errorf("--for-hosts: %w", err)
+			}
+		}
+	}
+
+	if opts.in != "" || opts.notIn != "" {
+		return handleMembership(inputs, opts)
+	}
+
+	if opts.validate {
+		return handleValidate(inputs, opts)
+	}
+
+	if opts.gaps {
+		return handleGaps(inputs, opts)
+	}
+
+	if opts.remaining != -1 {
+		return handleRemaining(inputs, opts)
+	}
+
+	if opts.fromJSON {
+		return handleFromJSON(opts)
+	}
+
+	if opts.groupFamily {
+		return handleGroupFamily(inputs, opts)
+	}
+
+	if opts.template != "" {
+		return handleTemplate(inputs, opts)
+	}
+
+	if opts.hcl && (len(inputs) > 1 || opts.file != "") {
+		return handleHCL(inputs, opts)
+	}
+
+	if opts.json || opts.ndjson || opts.jsonMap {
+		return handleJSON(inputs, opts)
+	}
+
+	if opts.proto {
+		return handleProto(inputs, opts)
+	}
+
+	if opts.nft || opts.ipset {
+		return handleFirewallSet(inputs, opts)
+	}
+
+	if opts.file != "" {
+		return handleBatch(opts.file, opts)
+	}
+
+	if opts.sweep != "" {
+		return handleSweep(opts.sweep, opts)
+	}
+
+	if opts.covers != "" {
+		return handleCovers(opts.covers, opts)
+	}
+
 	// Check for CIDR argument
-	flagArgs := fs.Args()
+	flagArgs := inputs
 	if len(flagArgs) < 1 {
 		printUsage()
 		return fmt.Errorf("no CIDR argument provided")
 	}
 
-	cidr := flagArgs[0]
+	if opts.isDoc {
+		return checkDocumentation(flagArgs[0])
+	}
+
+	if opts.isGlobal {
+		return checkGloballyRoutable(flagArgs[0], opts)
+	}
+
+	if opts.distance {
+		return handleDistance(flagArgs, opts)
+	}
+
+	if opts.allocMap {
+		return handleMap(flagArgs)
+	}
 
-	// Detect IP version and handle accordingly
-	if isIPv6CIDR(cidr) {
-		return handleIPv6(cidr, *showMask, *showBinary)
+	return handleInput(flagArgs[0], opts)
+}
+
+// handleInput dispatches a single CIDR or range argument to the matching handler
+func handleInput(cidr string, opts options) error {
+	if isDashRange(cidr) {
+		return handleRange(cidr)
+	}
+
+	if opts.delta != 0 {
+		return handleDelta(cidr, opts)
+	}
+
+	if opts.exclude != "" {
+		return handleExclude(cidr, opts.exclude)
+	}
+
+	// Detect IP version, unless forced, and handle accordingly
+	if opts.isIPv6(cidr) {
+		return handleIPv6(cidr, opts)
 	} else {
-		return handleIPv4(cidr)
+		return handleIPv4(cidr, opts)
+	}
+}
+
+// isIPv6 reports whether cidr, or a bare address, should be handled as IPv6, honouring --family
+// over detection
+func (o options) isIPv6(cidr string) bool {
+	if o.family != "" {
+		return o.family == "ipv6"
 	}
+
+	return isIPv6CIDR(cidr)
 }
 
 func isIPv6CIDR(cidr string) bool {
-	// Parse the CIDR to check if it's IPv6
-	ip, _, err := net.ParseCIDR(cidr)
-	if err != nil {
+	// net.ParseCIDR rejects a zone (fe80::1%eth0/64), so it's dropped before checking
+	address, prefix, hasPrefix := strings.Cut(cidr, "/")
+	address, _, _ = strings.Cut(address, "%")
+
+	// Parse the CIDR, or the bare address, to check if it's IPv6
+	ip := net.ParseIP(address)
+	if hasPrefix {
+		var err error
+		if ip, _, err = net.ParseCIDR(address + "/" + prefix); err != nil {
+			return false
+		}
+	}
+
+	if ip == nil {
 		return false
 	}
 
@@ -84,43 +431,665 @@ func isIPv6CIDR(cidr string) bool {
 	return strings.Contains(cidr, ":")
 }
 
-func handleIPv4(cidr string) error {
+// normalizeInput tidies a pasted argument or batch line before parsing by trimming whitespace and
+// dropping the brackets around an IPv6 address in URL and host:port style, e.g. "[2001:db8::1]/64"
+// or "[2001:db8::/32]". A port after the brackets, as in "[2001:db8::1]:443", is dropped too; one
+// that isn't a number leaves the input as it is, so it fails to parse rather than being run into
+// the address.
+func normalizeInput(s string) string {
+	s = strings.TrimSpace(s)
+
+	if rest, ok := strings.CutPrefix(s, "["); ok {
+		if before, after, found := strings.Cut(rest, "]"); found {
+			port, hasPort := strings.CutPrefix(after, ":")
+
+			switch {
+			case !hasPort:
+				s = before + after
+			case isPort(port):
+				s = before
+			}
+		}
+	}
+
+	return s
+}
+
+// isPort reports whether s is a decimal TCP or UDP port number
+func isPort(s string) bool {
+	port, err := strconv.ParseUint(s, 10, 16)
+
+	return err == nil && port > 0
+}
+
+// prefixForHosts returns the bare IPv4 address with the prefix length of the smallest network
+// holding hosts usable addresses, e.g. 10.0.0.0/23 for 500. The address must be the network address
+// of a block that size.
+func prefixForHosts(address string, hosts int) (string, error) {
+	ip := net.ParseIP(address)
+	if ip == nil || ip.To4() == nil {
+		return "", fmt.Errorf("%w: %q isn't a bare IPv4 address", ipv4.ErrInvalidAddress, address)
+	}
+
+	prefix, err := ipv4.PrefixForHosts(hosts)
+	if err != nil {
+		return "", err
+	}
+
+	if aligned := ipv4.MaxAlignedPrefix(ip); aligned > prefix {
+		return "", fmt.Errorf("%w: %d hosts need a /%d, but %s only starts blocks of /%d and longer",
+			ipv4.ErrHostOutOfRange, hosts, prefix, address, aligned)
+	}
+
+	return fmt.Sprintf("%s/%d", address, prefix), nil
+}
+
+// isDashRange reports whether the input is an IPv4 address range such as 10.0.0.0-255
+func isDashRange(input string) bool {
+	return strings.Contains(input, "-") && !strings.ContainsAny(input, "/:")
+}
+
+func handleRange(input string) error {
+	start, end, err := ipv4.ParseDashRange(input)
+	if err != nil {
+		return fmt.Errorf("invalid IPv4 range %q: %w", input, err)
+	}
+
+	networks, err := ipv4.RangeToCIDRs(start, end)
+	if err != nil {
+		return fmt.Errorf("failed to convert range to CIDRs: %w", err)
+	}
+
+	for _, network := range networks {
+		fmt.Println(network.String())
+	}
+
+	return nil
+}
+
+// parseAddress parses a bare address or the address part of a CIDR
+func parseAddress(input string) (net.IP, error) {
+	if strings.Contains(input, "/") {
+		ip, _, err := net.ParseCIDR(input)
+		if err != nil {
+			return nil, fmt.Errorf("net.ParseCIDR: %w", err)
+		}
+
+		return ip, nil
+	}
+
+	ip := net.ParseIP(input)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", input)
+	}
+
+	return ip, nil
+}
+
+func checkDocumentation(input string) error {
+	ip, err := parseAddress(input)
+	if err != nil {
+		return err
+	}
+
+	if ipv4.IsDocumentation(ip) || ipv6.IsDocumentation(ip) {
+		return nil
+	}
+
+	return errCheckFailed
+}
+
+// checkGloballyRoutable returns errCheckFailed unless the address would be routed on the public
+// internet. Addresses written with a colon are checked as IPv6 unless --family says otherwise, so
+// IPv4-mapped ones aren't routable.
+func checkGloballyRoutable(input string, opts options) error {
+	ip, err := parseAddress(input)
+	if err != nil {
+		return err
+	}
+
+	routable := ipv4.IsGloballyRoutable(ip)
+	if opts.isIPv6(input) {
+		routable = ipv6.IsGloballyRoutable(ip)
+	}
+
+	if !routable {
+		return errCheckFailed
+	}
+
+	return nil
+}
+
+func handleExclude(cidr, exclude string) error {
+	parent, err := ipv4.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", cidr, err)
+	}
+
+	remove, err := ipv4.ParseCIDR(exclude)
+	if err != nil {
+		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", exclude, err)
+	}
+
+	networks, err := parent.Exclude(remove)
+	if err != nil {
+		return fmt.Errorf("failed to exclude %s from %s: %w", exclude, cidr, err)
+	}
+
+	for _, network := range networks {
+		fmt.Println(network.String())
+	}
+
+	return nil
+}
+
+func handleIPv4(cidr string, opts options) error {
 	network, err := ipv4.ParseCIDR(cidr)
 	if err != nil {
 		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", cidr, err)
 	}
 
+	if opts.reverse {
+		return fmt.Errorf("--reverse is only supported for IPv6 networks")
+	}
+
+	if opts.eui64 != "" {
+		return fmt.Errorf("--eui64 is only supported for IPv6 networks")
+	}
+
+	if opts.advice {
+		return fmt.Errorf("--advice is only supported for IPv6 networks")
+	}
+
+	if opts.list64 {
+		return fmt.Errorf("--list64 is only supported for IPv6 networks")
+	}
+
+	if opts.preview != 0 {
+		return fmt.Errorf("--preview is only supported for IPv6 networks")
+	}
+
+	if opts.url {
+		return fmt.Errorf("--url is only supported for IPv6 networks")
+	}
+
+	if opts.rollUp {
+		return printRollUp(network)
+	}
+
+	position, err := parentPosition(network, opts.parent)
+	if err != nil {
+		return err
+	}
+
+	applyIPv4Format(network, opts)
+
 	err = network.Calculate()
 	if err != nil {
 		return fmt.Errorf("failed to calculate IPv4 network: %w", err)
 	}
 
-	fmt.Println(network.FormattedText())
+	if err := checkStrictNetwork(network, opts); err != nil {
+		return err
+	}
+
+	if opts.networkOnly {
+		fmt.Println(network.Network)
+		return nil
+	}
+
+	if opts.fingerprint {
+		fmt.Println(network.Fingerprint())
+		return nil
+	}
+
+	if opts.binaryOnly {
+		fmt.Println(ipv4.FormatBinaryWithMask(network.Address, network.PrefixLength))
+		return nil
+	}
+
+	if opts.zone != "" {
+		return printZoneFile(network.ZoneFileTemplate(opts.zone), network.String())
+	}
+
+	if opts.record != "" {
+		fmt.Println(network.ForwardRecord(opts.record))
+		return nil
+	}
+
+	if opts.ospf {
+		fmt.Println(network.OSPFStatement(opts.area))
+		return nil
+	}
+
+	if opts.eigrp {
+		fmt.Println(network.EIGRPStatement())
+		return nil
+	}
+
+	if opts.gateways {
+		printGateways(network.FirstUsable().String(), network.LastUsable().String(), opts)
+		return nil
+	}
+
+	if opts.hosts {
+		printHosts(network, opts.order)
+		return nil
+	}
+
+	if opts.split != -1 {
+		return printSplit(network, opts.split, opts.csv)
+	}
+
+	if opts.provision != -1 {
+		return printProvisioningTable(network, opts.provision)
+	}
+
+	if opts.magic {
+		octet, magic := network.MagicNumber()
+		printText(fmt.Sprintf("     Magic:\t%d\n     Octet:\t%d", magic, octet+1), opts)
+
+		return nil
+	}
+
+	if opts.sipcalc {
+		fmt.Println(network.SipcalcText())
+		return nil
+	}
+
+	if opts.hcl {
+		fmt.Println(network.HCLText())
+		return nil
+	}
+
+	printText(ipv4Text(network, opts), opts)
+
+	if opts.bothCounts {
+		printBothCounts(network, opts)
+	}
+
+	if opts.anatomy {
+		networkBits, hostBits := network.BitCounts()
+		printText(fmt.Sprintf("    Prefix:\t%d network bits, %d host bits; %d addresses, %d usable",
+			networkBits, hostBits, network.TotalAddresses(), network.UsableHosts()), opts)
+	}
+
+	if opts.octetValues {
+		printText(network.OctetValuesText(), opts)
+	}
+
+	if position != "" {
+		printText(fmt.Sprintf("    Parent:\t%s", position), opts)
+	}
 
 	return nil
 }
 
-func handleIPv6(cidr string, showMask, showBinary bool) error {
+// printRollUp prints the supernet the network could be merged into and the sibling it would be
+// merged with
+func printRollUp(network *ipv4.Network) error {
+	supernet, sibling := network.RollUp()
+	if supernet == nil {
+		return fmt.Errorf("%s is the whole address space and has no supernet", network)
+	}
+
+	fmt.Printf("  Supernet:\t%s\n   Sibling:\t%s\n", supernet, sibling)
+
+	return nil
+}
+
+// printProvisioningTable prints the /newPrefix subnets of network one per line with their gateway
+// and broadcast address, in columns ready to paste into provisioning templates
+func printProvisioningTable(network *ipv4.Network, newPrefix int) error {
+	rows, err := network.ProvisioningTable(newPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to split %s into /%d subnets: %w", network, newPrefix, err)
+	}
+
+	fmt.Printf("%-18s  %-15s  %s\n", "Subnet", "Gateway", "Broadcast")
+
+	for _, row := range rows {
+		fmt.Printf("%-18s  %-15s  %s\n", row.Subnet, row.Gateway, row.Broadcast)
+	}
+
+	return nil
+}
+
+// list64s prints the first limit /64 LANs within network, one per line
+func list64s(network *ipv6.Network, limit int) error {
+	if limit < 1 {
+		return fmt.Errorf("invalid --limit %d, expected at least 1", limit)
+	}
+
+	lans, err := network.List64s(limit)
+	if err != nil {
+		return fmt.Errorf("failed to list the /64s of %s: %w", network, err)
+	}
+
+	for _, lan := range lans {
+		fmt.Println(lan)
+	}
+
+	return nil
+}
+
+// printPreview prints the first and last --preview addresses of the network, with an ellipsis
+// between them when the rest of the network is left out
+func printPreview(network *ipv6.Network, opts options) {
+	first, last := network.Preview(opts.preview)
+
+	label := "Preview:"
+	for _, ip := range first {
+		printText(fmt.Sprintf("%10s\t%s", label, ip), opts)
+		label = ""
+	}
+
+	if len(last) == 0 {
+		return
+	}
+
+	printText(fmt.Sprintf("%10s\t...", ""), opts)
+
+	for _, ip := range last {
+		printText(fmt.Sprintf("%10s\t%s", "", ip), opts)
+	}
+}
+
+// parentPosition describes where network sits among the same-size subnets of the --parent CIDR,
+// e.g. "subnet 3 of 4 within 192.168.1.0/24", counting from 0. It returns "" when no parent was
+// given.
+func parentPosition(network *ipv4.Network, parent string) (string, error) {
+	if parent == "" {
+		return "", nil
+	}
+
+	parentNetwork, err := ipv4.ParseCIDR(parent)
+	if err != nil {
+		return "", fmt.Errorf("invalid IPv4 CIDR notation %q: %w", parent, err)
+	}
+
+	index, total, err := network.IndexWithin(parentNetwork)
+	if err != nil {
+		return "", fmt.Errorf("failed to place %s within %s: %w", network, parent, err)
+	}
+
+	return fmt.Sprintf("subnet %d of %d within %s (counting from 0)", index, total, parentNetwork), nil
+}
+
+// applyIPv4Format sets the network's format options and custom ranges from the display flags,
+// before it's calculated
+func applyIPv4Format(network *ipv4.Network, opts options) {
+	network.Format.NoClass = opts.noClass
+	network.Format.BinaryWrap = opts.binaryWrap
+	network.CustomRanges = opts.ranges.ipv4
+}
+
+// applyIPv6Format sets the network's format options and custom ranges from the display flags,
+// before it's calculated
+func applyIPv6Format(network *ipv6.Network, opts options) {
+	network.Format.HumanCount = opts.countHuman
+	network.Format.NoClass = opts.noClass
+	network.Format.Mixed = opts.ipv6Mixed
+	network.Format.RIR = opts.rir
+	network.Format.BinaryWrap = opts.binaryWrap
+	network.CustomRanges = opts.ranges.ipv6
+}
+
+// ipv4Text returns the usual output for a calculated IPv4 network: plain, without binary, or in
+// full
+func ipv4Text(network *ipv4.Network, opts options) string {
+	switch {
+	case opts.plain:
+		return network.PlainText()
+	case opts.noBinary:
+		return network.FormattedTextNoBinary()
+	default:
+		return network.FormattedText()
+	}
+}
+
+// ipv6Text returns the usual output for a calculated IPv6 network: plain, or with the mask and
+// binary rows --ipv6-mask and --ipv6-binary ask for
+func ipv6Text(network *ipv6.Network, opts options) string {
+	switch {
+	case opts.plain:
+		return network.PlainText()
+	case opts.ipv6Mask && opts.ipv6Binary:
+		return network.FormattedTextWithMask()
+	case opts.ipv6Mask:
+		return network.FormattedTextWithMaskNoBinary()
+	case opts.ipv6Binary:
+		return network.FormattedTextWithBinary()
+	default:
+		return network.FormattedText()
+	}
+}
+
+// printText prints formatted text, expanding its tabs to spaces when --tabsize or --spaces is set
+func printText(text string, opts options) {
+	if opts.tabSize > 0 {
+		text = layout.ExpandTabs(text, opts.tabSize)
+	}
+
+	fmt.Println(text)
+}
+
+// checkStrictNetwork returns an error wrapping errStrictNetwork if --strict-network is set and the
+// calculated network's address has host bits set
+func checkStrictNetwork(network interface{ CheckNetworkAddress() error }, opts options) error {
+	if !opts.strictNet {
+		return nil
+	}
+
+	if err := network.CheckNetworkAddress(); err != nil {
+		return fmt.Errorf("%w: %w", errStrictNetwork, err)
+	}
+
+	return nil
+}
+
+// printBothCounts prints the usable host count and the total address count on separate lines. A
+// /31 has no network or broadcast address (RFC 3021) and a /32 is a single host, so every address
+// is usable.
+func printBothCounts(network *ipv4.Network, opts options) {
+	if network.PrefixLength == 31 {
+		printText(fmt.Sprintf("Usable (RFC 3021): %d, Total: %d", network.UsableHosts(), network.TotalAddresses()), opts)
+		return
+	}
+
+	printText(fmt.Sprintf("Usable hosts: %d\nTotal addresses: %d", network.UsableHosts(), network.TotalAddresses()), opts)
+}
+
+func handleIPv6(cidr string, opts options) error {
 	network, err := ipv6.ParseCIDR(cidr)
 	if err != nil {
 		return fmt.Errorf("invalid IPv6 CIDR notation %q: %w", cidr, err)
 	}
 
+	if opts.hosts {
+		return fmt.Errorf("--hosts is only supported for IPv4 networks")
+	}
+
+	if opts.magic {
+		return fmt.Errorf("--magic is only supported for IPv4 networks")
+	}
+
+	if opts.parent != "" {
+		return fmt.Errorf("--parent is only supported for IPv4 networks")
+	}
+
+	if opts.split != -1 {
+		return fmt.Errorf("--split is only supported for IPv4 networks")
+	}
+
+	if opts.provision != -1 {
+		return fmt.Errorf("--provision is only supported for IPv4 networks")
+	}
+
+	if opts.rollUp {
+		return fmt.Errorf("--rollup is only supported for IPv4 networks")
+	}
+
+	if opts.eigrp {
+		return fmt.Errorf("--eigrp is only supported for IPv4 networks")
+	}
+
+	if opts.list64 {
+		return list64s(network, opts.limit)
+	}
+
+	applyIPv6Format(network, opts)
+
 	err = network.Calculate()
 	if err != nil {
 		return fmt.Errorf("failed to calculate IPv6 network: %w", err)
 	}
 
-	if showMask && showBinary {
-		fmt.Println(network.FormattedTextWithMask())
-	} else if showMask {
-		fmt.Println(network.FormattedTextWithMaskNoBinary())
-	} else if showBinary {
-		fmt.Println(network.FormattedTextWithBinary())
-	} else {
-		fmt.Println(network.FormattedText())
+	if err := checkStrictNetwork(network, opts); err != nil {
+		return err
+	}
+
+	if opts.networkOnly {
+		fmt.Println(network.NetworkAddress())
+		return nil
+	}
+
+	if opts.fingerprint {
+		fmt.Println(network.Fingerprint())
+		return nil
+	}
+
+	if opts.binaryOnly {
+		fmt.Println(ipv6.FormatBinaryWithMask(network.Address, network.PrefixLength))
+		return nil
+	}
+
+	if opts.zone != "" {
+		return printZoneFile(network.ZoneFileTemplate(opts.zone), network.String())
+	}
+
+	if opts.reverse {
+		zone, prefix := network.NibbleAlignedZone()
+		fmt.Printf("      Zone:\t%s\nDelegation:\t/%d\n", zone, prefix)
+
+		return nil
+	}
+
+	if opts.record != "" {
+		fmt.Println(network.ForwardRecord(opts.record))
+		return nil
+	}
+
+	if opts.url {
+		fmt.Println(network.URLForm(opts.port))
+		return nil
+	}
+
+	if opts.ospf {
+		fmt.Println(network.OSPFStatement(opts.area))
+		return nil
+	}
+
+	if opts.eui64 != "" {
+		return printEUI64Address(network, opts.eui64)
+	}
+
+	if opts.gateways {
+		printGateways(network.FirstUsable().String(), network.LastUsable().String(), opts)
+		return nil
+	}
+
+	if opts.sipcalc {
+		fmt.Println(network.SipcalcText())
+		return nil
+	}
+
+	if opts.hcl {
+		fmt.Println(network.HCLText())
+		return nil
+	}
+
+	printText(ipv6Text(network, opts), opts)
+
+	if opts.bothCounts {
+		printText(fmt.Sprintf("Usable hosts: %s\nTotal addresses: %s", network.HostCount, network.TotalAddresses()), opts)
 	}
 
+	if opts.anatomy {
+		networkBits, hostBits := network.BitCounts()
+		printText(fmt.Sprintf("    Prefix:\t%d network bits, %d host bits; %s addresses, %s usable",
+			networkBits, hostBits, network.TotalAddresses(), network.HostCount), opts)
+	}
+
+	if opts.preview != 0 {
+		printPreview(network, opts)
+	}
+
+	if opts.octetValues {
+		printText(network.HextetValuesText(), opts)
+	}
+
+	if opts.advice {
+		for _, note := range network.PlanningNotes() {
+			printText(fmt.Sprintf("    Advice:\t%s", note), opts)
+		}
+	}
+
+	return nil
+}
+
+// printEUI64Address prints the SLAAC address the MAC autoconfigures in the network
+func printEUI64Address(network *ipv6.Network, mac string) error {
+	hardwareAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return fmt.Errorf("net.ParseMAC: %w", err)
+	}
+
+	ip, err := network.AddressFromMAC(hardwareAddr)
+	if err != nil {
+		return fmt.Errorf("network.AddressFromMAC: %w", err)
+	}
+
+	fmt.Println(ip)
+
+	return nil
+}
+
+func printReference(family string) error {
+	switch family {
+	case "ipv4":
+		fmt.Print(ipv4.ReferenceTable())
+	case "ipv6":
+		fmt.Print(ipv6.ReferenceTable())
+	default:
+		return fmt.Errorf("unknown reference family %q, expected ipv4 or ipv6", family)
+	}
+
+	return nil
+}
+
+func printHosts(network *ipv4.Network, order string) {
+	hosts := network.Hosts()
+	if order == "desc" {
+		hosts = network.HostsDescending()
+	}
+
+	for ip := range hosts {
+		fmt.Println(ip.String())
+	}
+}
+
+func printGateways(gateway, secondary string, opts options) {
+	printText(fmt.Sprintf("   Gateway:\t%s\n Secondary:\t%s", gateway, secondary), opts)
+}
+
+func printZoneFile(zone, cidr string) error {
+	if zone == "" {
+		return fmt.Errorf("network %s is too large for a zone file template, use /24 or longer for IPv4 and /64 or longer for IPv6", cidr)
+	}
+
+	fmt.Print(zone)
+
 	return nil
 }
 
@@ -129,20 +1098,208 @@ func printUsage() {
 
 Usage:
   ripcalc [OPTIONS] <CIDR>
+  ripcalc --reference ipv4|ipv6
+  ripcalc <RANGE>
+  ripcalc [OPTIONS] --file <PATH>
+  ripcalc [OPTIONS] --sweep <ADDRESS> [--from N] [--to N]
+  ripcalc --covers <ADDRESS>
+  ripcalc --nft|--ipset [--file <PATH>] [CIDR...]
+  ripcalc --json|--ndjson|--json-map [--file <PATH>] [CIDR...]
+  ripcalc --template <TEXT> [--file <PATH>] [CIDR...]
+  ripcalc --proto [--file <PATH>] [CIDR...]
+  ripcalc --in|--not-in <ADDRESS> [--file <PATH>] [CIDR...]
+  ripcalc --validate [--file <PATH>] [CIDR...]
+  ripcalc --gaps [--file <PATH>] [CIDR...]
 
 Arguments:
   CIDR    IPv4 or IPv6 address in CIDR notation
+  RANGE   IPv4 address range, e.g. 10.0.0.5-10.0.0.20 or 10.0.0.0-255,
+          printed as the minimal list of covering CIDRs
 
 Options:
   -h, --help         Show this help message
+      --family FAMILY
+                     Read the input as ipv4 or ipv6 instead of detecting the family
       --ipv6-mask    Show netmask and wildcard for IPv6 (always shown for IPv4)
       --ipv6-binary  Show binary representation for IPv6 (always shown for IPv4)
+      --ipv6-mixed   Show IPv4-embedded IPv6 addresses with a dotted-quad tail
+      --advice       Print IPv6 planning advice for the prefix length, e.g. /64 for LANs
+      --rir          Show the regional registry responsible for IPv6 global unicast addresses
+      --count-human  Show large IPv6 host counts in word-scale units as well
+      --binary-only  Print only the address in binary, with a space at the network/host
+                     boundary
+      --octet-values Also print each octet of the address in binary under the place values
+                     128 to 1 with its decimal value, or each IPv6 hextet by nibble with its
+                     hex and decimal value
+      --no-binary    Hide binary representation for IPv4
+      --tabsize N    Expand tabs in the output to spaces, with tab stops every N columns
+      --spaces       Expand tabs in the output to spaces, with tab stops every 8 columns
+      --binary-wrap N
+                     Break the binary representation onto a new line every N groups
+      --plain        Align with spaces instead of tabs and hide binary, for pasting into chat
+      --zone DOMAIN  Print a reverse DNS zone file template with PTR records under DOMAIN
+      --eui64 MAC    Print the SLAAC (modified EUI-64) address MAC autoconfigures in an
+                     IPv6 /64
+      --reverse      Print the nibble-aligned ip6.arpa zone for delegating an IPv6 network
+      --record NAME  Print a forward DNS A or AAAA record for the address under NAME
+      --url          Print the IPv6 address in brackets for a URL or connection string,
+                     e.g. "[2001:db8::1]", with any zone escaped as %%25
+      --port N       Port --url appends, e.g. "[2001:db8::1]:443"
+      --ospf         Print the OSPF statement enabling the network, e.g. "network 10.0.0.0
+                     0.0.0.255 area 0", or the OSPFv3 "area 0 range" form for IPv6
+      --area N       OSPF area for --ospf (default 0)
+      --eigrp        Print the EIGRP statement enabling an IPv4 network, e.g. "network
+                     10.0.0.0 0.0.0.255"
+      --ranges-file PATH
+                     Classify using custom ranges from PATH first, one "cidr label" per line,
+                     e.g. "100.127.0.0/16 Corp DMZ"
+      --no-class     Omit the address class and type from the output
+      --both-counts  Also print the usable host count and the total address count
+      --anatomy      Also print how the prefix splits into network and host bits, with the
+                     total and usable address counts
+      --fingerprint  Print a short stable hash of the canonical network, for spotting when a
+                     normalised prefix changes between runs
+      --parent CIDR  Also print the IPv4 subnet's position among its same-size siblings
+                     within CIDR, e.g. "subnet 3 of 4 within 192.168.1.0/24"
+      --rollup       Print the IPv4 supernet one bit shorter that the network rolls up into and
+                     the sibling that would complete it
+      --strict-network
+                     Fail with an error if the address has host bits set below the prefix,
+                     e.g. 10.0.0.5/24, to lint configs that should name networks cleanly
+      --network-only Print only the network (base) address, without the prefix length
+      --gateways     Print the suggested gateway (first usable) and secondary (last usable)
+      --sweep ADDRESS
+                     Print the calculation for ADDRESS at every prefix length from --from
+                     (default 0) to --to (default /32 or /128)
+      --from N       First prefix length of a --sweep
+      --to N         Last prefix length of a --sweep
+      --covers ADDRESS
+                     Print the network containing ADDRESS at every prefix length, one per
+                     line from /0 to the host route; IPv6 steps by nibble (/0, /4 ... /128)
+      --gaps         Print the CIDRs of IPv4 space left uncovered between the lowest and
+                     highest CIDR arguments and --file lines, to check a plan is contiguous
+      --map          Draw a bar showing how much of the first IPv4 CIDR is allocated to the
+                     CIDRs that follow it, with percentages
+      --distance     Print the signed number of addresses from the first CIDR's base address
+                     to the second's
+      --delta N      Show the network with its prefix lengthened (+N) or shortened (-N) by
+                     N bits, and how the size changes
+      --for-hosts N  Calculate the smallest IPv4 network starting at the bare address that
+                     fits N usable hosts, e.g. a /23 for 500
+      --remaining N  Print how many aligned /N subnets of the first IPv4 CIDR are still free,
+                     given the allocated CIDRs that follow it and the --file lines
+      --split N      Print each /N subnet of the IPv4 network, one per line
+      --csv          With --split, print a CSV row per subnet with its netmask, network,
+                     broadcast, first and last hosts and host count
+      --provision N  Print each /N subnet of the IPv4 network with its gateway (first usable)
+                     and broadcast address, for DHCP and router templates
+      --magic        Print the subnetting magic number (block size) and the octet, counted
+                     from 1, where subnets start at its multiples
+      --list64       List the /64 LANs within an IPv6 network, e.g. to assign them to VLANs
+      --limit N      Most /64s --list64 prints (default 256)
+      --preview N    Also list the first N and last N addresses of an IPv6 network, to get a
+                     feel for the space
+      --hosts        List every usable IPv4 host address, one per line
+      --order ORDER  Order of the --hosts listing: asc (default) or desc
+      --json         Print the result as JSON, an array when there are several inputs
+      --ndjson       Print one JSON object per line per input as each is calculated
+      --json-map     Print one JSON object mapping each input to its result, with an
+                     {"error": ...} entry for inputs that can't be calculated
+      --from-json    Read ripcalc --json or --ndjson output from stdin, or --file, and print
+                     each network again with the other flags, e.g. as text at the end of a
+                     pipeline
+      --template TEXT
+                     Render each result with a Go text/template, e.g. "{{.CIDR}} has
+                     {{.HostCount}} hosts". Fields: Family, Address, Prefix, CIDR, Network,
+                     Netmask, Wildcard, FirstHost, LastHost, Broadcast (IPv4 only),
+                     HostCount, TotalAddresses, ClassType and Scope
+      --proto        Write each result as a length-prefixed binary message, decodable with
+                     the github.com/ronny/ripcalc/wire package
+      --sipcalc      Print the result with sipcalc's field labels and layout, as a drop-in for
+                     scripts that parse sipcalc
+      --hcl          Print the result as a Terraform/HCL object, or a list of objects for
+                     several inputs or --file, with each line's label as a label attribute
+      --file PATH    Read CIDRs from PATH (- for stdin), one per line with an optional
+                     leading label, e.g. "webservers 10.0.1.0/24"
+      --group-family Print every IPv4 result and then every IPv6 one, each sorted by
+                     address under its own heading; with --json, one object with "ipv4"
+                     and "ipv6" arrays
+      --jobs N       Calculate --file lines on N workers in parallel, printing the results in
+                     input order; only the display flags such as --no-binary can be combined
+      --total        After a --file batch, print the number of networks and their total
+                     usable hosts
+      --nft          Print every CIDR argument and --file line as nftables set blocks
+      --ipset        Print every CIDR argument and --file line as an ipset restore script
+      --in ADDRESS   Exit 0 if ADDRESS is inside any CIDR argument or --file line, printing
+                     the first that contains it, and 1 otherwise
+      --not-in ADDRESS
+                     Exit 0 if ADDRESS is outside every CIDR argument and --file line, and 1
+                     (printing the first that contains it) otherwise
+      --is-global    Exit 0 if the address is routable on the public internet, i.e. outside
+                     every private, loopback, link-local, CGNAT, documentation, multicast and
+                     reserved range, 1 otherwise
+      --validate     Check that every CIDR argument and --file line is a valid CIDR without
+                     calculating it, printing nothing and exiting 0 if so, or printing the
+                     first error and exiting 1
+      --is-doc       Exit 0 if the address is reserved for documentation, 1 otherwise
+      --exclude CIDR Print the IPv4 CIDRs left after removing CIDR from the network
+      --reference FAMILY
+                     Print a subnetting reference table for ipv4 or ipv6
 
 Examples:
   IPv4:
     ripcalc 192.168.0.0/24
     ripcalc 10.0.0.1/16
     ripcalc 172.16.0.0/12
+    ripcalc --no-binary 192.168.0.0/24
+    ripcalc --binary-only 192.168.0.1/24
+    ripcalc --octet-values 192.168.0.1/24
+    ripcalc --plain 192.168.0.0/24
+    ripcalc --spaces 192.168.0.0/24
+    ripcalc 192.168/16
+    ripcalc 192.168.0.0/255.255.254.0
+    ripcalc 192.168.0.0/0xfffffe00
+    ripcalc --zone example.com 192.168.1.16/28
+    ripcalc 10.0.0.5-10.0.0.20
+    ripcalc --gateways 10.0.0.0/24
+    ripcalc --network-only 10.0.0.5/24
+    ripcalc --strict-network 10.0.0.0/24
+    ripcalc --fingerprint 10.0.0.5/24
+    ripcalc --both-counts 10.0.0.0/24
+    ripcalc --parent 192.168.1.0/24 192.168.1.192/26
+    ripcalc --rollup 10.0.1.0/24
+    ripcalc --ospf --area 1 10.0.0.0/24
+    ripcalc --eigrp 10.0.0.0/24
+    ripcalc --exclude 10.0.0.64/26 10.0.0.0/24
+    ripcalc --is-doc 198.51.100.7
+    ripcalc --is-global 100.64.0.1
+    ripcalc --validate --file prefixes.txt
+    ripcalc --not-in 10.0.0.5 --file blocklist.txt
+    ripcalc --ranges-file custom.txt 100.127.4.0/24
+    ripcalc --file inventory.txt
+    ripcalc --total --file inventory.txt
+    ripcalc --jobs 8 --no-binary --file routes.txt
+    ripcalc --group-family --file mixed.txt
+    ripcalc --hosts --order desc 10.0.0.0/29
+    ripcalc --magic 10.0.0.0/26
+    ripcalc --anatomy 10.0.0.0/26
+    ripcalc --split 26 --csv 10.0.0.0/24
+    ripcalc --provision 26 10.0.0.0/24
+    ripcalc --for-hosts 500 10.0.0.0
+    ripcalc --delta +1 10.0.0.0/24
+    ripcalc --distance 10.0.0.0/24 10.0.4.0/24
+    ripcalc --gaps --file blocks.txt
+    ripcalc --map 10.0.0.0/24 10.0.0.0/26 10.0.0.128/27
+    ripcalc --remaining 26 --file allocated.txt 10.0.0.0/24
+    ripcalc --sweep 10.0.0.0 --from 24 --to 28
+    ripcalc --covers 10.0.0.5
+    ripcalc --nft 10.0.0.0/24 192.168.0.0/16 2001:db8::/32
+    ripcalc --json 192.168.0.1/24
+    ripcalc --sipcalc 192.168.1.0/24
+    ripcalc --ndjson --file - < prefixes.txt
+    ripcalc --json 10.0.0.0/24 192.168.0.0/16 | ripcalc --from-json --no-binary
+    ripcalc --json-map 10.0.0.0/24 192.168.0.0/16
+    ripcalc --template '{{.Network}}/{{.Prefix}} has {{.HostCount}} hosts' 10.0.0.0/24
 
   IPv6:
     ripcalc 2001:db8::/64
@@ -151,6 +1308,18 @@ Examples:
     ripcalc --ipv6-mask 2001:db8::/64
     ripcalc --ipv6-binary 2001:db8::/64
     ripcalc --ipv6-mask --ipv6-binary 2001:db8::/64
+    ripcalc --count-human 2001:db8::/64
+    ripcalc --rir 2a00:1450::/32
+    ripcalc --advice 2001:db8::/120
+    ripcalc --list64 --limit 16 2001:db8::/56
+    ripcalc --preview 3 2001:db8::/64
+    ripcalc --ipv6-binary --binary-wrap 4 2001:db8::/64
+    ripcalc --family ipv6 ::ffff:192.0.2.1/128
+    ripcalc --record www.example.com 2001:db8::1/64
+    ripcalc --url --port 443 2001:db8::1/64
+    ripcalc --ospf 2001:db8::/64
+    ripcalc --reverse 2001:db8:ab:cd00::/56
+    ripcalc --eui64 00:11:22:33:44:55 fe80::/64
 
 `)
 }
@@ -2,8 +2,11 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -107,6 +110,54 @@ func TestRunWithDifferentNetworks(t *testing.T) {
 	}
 }
 
+func TestNormalizeInput(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"10.0.0.0/24", "10.0.0.0/24"},
+		{"  10.0.0.0/24\t", "10.0.0.0/24"},
+		{"[2001:db8::1]/64", "2001:db8::1/64"},
+		{"[2001:db8::/32]", "2001:db8::/32"},
+		{" [2001:db8::1]/64 ", "2001:db8::1/64"},
+		{"2001:db8::/32", "2001:db8::/32"},
+		{"[2001:db8::1]:443", "2001:db8::1"},
+		{"[2001:db8::1]:http", "[2001:db8::1]:http"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := normalizeInput(tt.input); got != tt.want {
+				t.Errorf("normalizeInput(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizedArguments(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"[2001:db8::1]/64", "2001:db8::/64"},
+		{"  192.168.1.0/24  ", "192.168.1.0/24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs([]string{"ripcalc", "--no-binary", tt.arg}); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if !strings.Contains(output, tt.expected) {
+				t.Errorf("Output missing %q:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
+
 func TestIsIPv6CIDR(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -143,6 +194,16 @@ func TestIsIPv6CIDR(t *testing.T) {
 			cidr:     "::ffff:192.168.1.1/128",
 			expected: true,
 		},
+		{
+			name:     "bare IPv6 address",
+			cidr:     "fe80::1%eth0",
+			expected: true,
+		},
+		{
+			name:     "bare IPv4 address",
+			cidr:     "192.168.1.1",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -158,9 +219,9 @@ func TestIsIPv6CIDR(t *testing.T) {
 // Integration tests that verify actual CLI output
 func TestIntegration_IPv4_Output(t *testing.T) {
 	tests := []struct {
-		name              string
-		cidr              string
-		expectedElements  []string
+		name                   string
+		cidr                   string
+		expectedElements       []string
 		expectedBinaryElements []string
 	}{
 		{
@@ -367,33 +428,33 @@ func captureStdout(t *testing.T, fn func()) string {
 
 func TestIPv6Flags(t *testing.T) {
 	tests := []struct {
-		name          string
-		args          []string
-		shouldHaveMask bool
+		name             string
+		args             []string
+		shouldHaveMask   bool
 		shouldHaveBinary bool
 	}{
 		{
-			name: "default - no flags",
-			args: []string{"ripcalc", "2001:db8::/64"},
-			shouldHaveMask: false,
+			name:             "default - no flags",
+			args:             []string{"ripcalc", "2001:db8::/64"},
+			shouldHaveMask:   false,
 			shouldHaveBinary: false,
 		},
 		{
-			name: "ipv6-mask only",
-			args: []string{"ripcalc", "--ipv6-mask", "2001:db8::/64"},
-			shouldHaveMask: true,
+			name:             "ipv6-mask only",
+			args:             []string{"ripcalc", "--ipv6-mask", "2001:db8::/64"},
+			shouldHaveMask:   true,
 			shouldHaveBinary: false,
 		},
 		{
-			name: "ipv6-binary only",
-			args: []string{"ripcalc", "--ipv6-binary", "2001:db8::/64"},
-			shouldHaveMask: false,
+			name:             "ipv6-binary only",
+			args:             []string{"ripcalc", "--ipv6-binary", "2001:db8::/64"},
+			shouldHaveMask:   false,
 			shouldHaveBinary: true,
 		},
 		{
-			name: "both flags",
-			args: []string{"ripcalc", "--ipv6-mask", "--ipv6-binary", "2001:db8::/64"},
-			shouldHaveMask: true,
+			name:             "both flags",
+			args:             []string{"ripcalc", "--ipv6-mask", "--ipv6-binary", "2001:db8::/64"},
+			shouldHaveMask:   true,
 			shouldHaveBinary: true,
 		},
 	}
@@ -431,3 +492,1201 @@ func TestIPv6Flags(t *testing.T) {
 		})
 	}
 }
+
+func TestCountHumanFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--count-human", "2001:db8::/96"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "2^32 (4.2 billion)") {
+		t.Errorf("Output missing human count\nFull output:\n%s", output)
+	}
+}
+
+func TestNoBinaryFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--no-binary", "192.168.1.0/24"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if strings.Contains(output, "11000000.10101000") {
+		t.Errorf("Output should not contain binary\nFull output:\n%s", output)
+	}
+
+	for _, element := range []string{"192.168.1.0/24", "255.255.255.0", "192.168.1.255", "254"} {
+		if !strings.Contains(output, element) {
+			t.Errorf("Output missing expected element: %q\nFull output:\n%s", element, output)
+		}
+	}
+}
+
+func TestZoneFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--zone", "example.com", "192.168.1.16/28"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "$ORIGIN 1.168.192.in-addr.arpa.") {
+		t.Errorf("Output missing $ORIGIN\nFull output:\n%s", output)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--zone", "example.com", "10.0.0.0/8"})
+	if err == nil || !strings.Contains(err.Error(), "too large") {
+		t.Errorf("Expected too large error, got: %v", err)
+	}
+}
+
+func TestReferenceFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--reference", "ipv4"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "255.255.255.192") {
+		t.Errorf("Output missing /26 netmask\nFull output:\n%s", output)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--reference", "ipx"})
+	if err == nil {
+		t.Error("Expected error for unknown reference family")
+	}
+}
+
+func TestDashRangeInput(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "10.0.0.0-127"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if strings.TrimSpace(output) != "10.0.0.0/25" {
+		t.Errorf("Output = %q, expected 10.0.0.0/25", output)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "10.0.0.20-5"})
+	if err == nil {
+		t.Error("Expected error for descending range")
+	}
+}
+
+func TestGatewaysFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "IPv4",
+			args:     []string{"10.0.0.0/24"},
+			expected: []string{"Gateway:\t10.0.0.1", "Secondary:\t10.0.0.254"},
+		},
+		{
+			name:     "IPv6",
+			args:     []string{"2001:db8::/64"},
+			expected: []string{"Gateway:\t2001:db8::1", "Secondary:\t2001:db8::ffff:ffff:ffff:ffff"},
+		},
+		{
+			name:     "tab size",
+			args:     []string{"--tabsize", "4", "10.0.0.0/24"},
+			expected: []string{"   Gateway: 10.0.0.1\n Secondary: 10.0.0.254\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs(append([]string{"ripcalc", "--gateways"}, tt.args...))
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			for _, element := range tt.expected {
+				if !strings.Contains(output, element) {
+					t.Errorf("Output missing expected element: %q\nFull output:\n%s", element, output)
+				}
+			}
+		})
+	}
+}
+
+func TestNoClassFlag(t *testing.T) {
+	tests := []struct {
+		name         string
+		cidr         string
+		unexpected   []string
+		expectedLine string
+	}{
+		{
+			name:         "IPv4",
+			cidr:         "192.168.1.0/24",
+			unexpected:   []string{"Class", "Private Internet"},
+			expectedLine: "Host count:\t254\n",
+		},
+		{
+			name:         "IPv6",
+			cidr:         "fd00::/64",
+			unexpected:   []string{"Unique Local Address", "Private"},
+			expectedLine: "Host count:\t2^64\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--no-class", tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			for _, element := range tt.unexpected {
+				if strings.Contains(output, element) {
+					t.Errorf("Output contains unexpected element: %q\nFull output:\n%s", element, output)
+				}
+			}
+
+			if !strings.Contains(output, tt.expectedLine) {
+				t.Errorf("Output missing %q\nFull output:\n%s", tt.expectedLine, output)
+			}
+		})
+	}
+}
+
+func TestHCLFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--hcl", "10.0.0.0/24"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	output = strings.TrimSpace(output)
+	if !strings.HasPrefix(output, "{ ") || !strings.HasSuffix(output, " }") {
+		t.Errorf("Output is not an HCL object: %q", output)
+	}
+
+	for _, element := range []string{`cidr = "10.0.0.0/24"`, `hosts = 254`} {
+		if !strings.Contains(output, element) {
+			t.Errorf("Output missing expected element: %q\nFull output:\n%s", element, output)
+		}
+	}
+}
+
+func TestIPv6MixedFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--ipv6-mixed", "64:ff9b::c000:201/128"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "64:ff9b::192.0.2.1") {
+		t.Errorf("Output missing mixed notation\nFull output:\n%s", output)
+	}
+}
+
+func TestRIRFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--rir", "2a00:1450::/32"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "Internet Routable (RIPE NCC)") {
+		t.Errorf("Output missing registry suffix\nFull output:\n%s", output)
+	}
+}
+
+func TestEUI64Flag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--eui64", "00:11:22:33:44:55", "fe80::/64"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if output != "fe80::211:22ff:fe33:4455\n" {
+		t.Errorf("Output = %q, want the EUI-64 address", output)
+	}
+
+	for _, args := range [][]string{
+		{"--eui64", "00:11:22:33:44:55", "2001:db8::/48"},
+		{"--eui64", "not-a-mac", "fe80::/64"},
+		{"--eui64", "00:11:22:33:44:55", "10.0.0.0/24"},
+	} {
+		if err := runWithArgs(append([]string{"ripcalc"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestNetmaskInputBinary(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "192.168.0.0/255.255.254.0"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	for _, element := range []string{"/23", "11000000.10101000.0000000 0.00000000"} {
+		if !strings.Contains(output, element) {
+			t.Errorf("Output missing expected element: %q\nFull output:\n%s", element, output)
+		}
+	}
+}
+
+func TestAdviceFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--advice", "2001:db8::/120"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "    Advice:\t/120 on a LAN will break SLAAC; use /64\n") {
+		t.Errorf("Output missing SLAAC advice\nFull output:\n%s", output)
+	}
+
+	if err := runWithArgs([]string{"ripcalc", "--advice", "10.0.0.0/24"}); err == nil {
+		t.Error("--advice should fail for IPv4 networks")
+	}
+}
+
+func TestNetworkOnlyFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"10.0.0.5/24", "10.0.0.0\n"},
+		{"2001:db8:0:0:ab::1/64", "2001:db8::\n"},
+		{"fe80::1%eth0/64", "fe80::\n"},
+		{"::ffff:1.2.3.4/120", "::ffff:102:300\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--network-only", tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStrictNetworkFlag(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		wantErr bool
+	}{
+		{"10.0.0.5/24", true},
+		{"10.0.0.0/24", false},
+		{"2001:db8::1/64", true},
+		{"2001:db8::/64", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			var err error
+			captureStdout(t, func() {
+				err = runWithArgs([]string{"ripcalc", "--strict-network", tt.cidr})
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runWithArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !strings.Contains(err.Error(), "host bits set") {
+				t.Errorf("error = %q, want it to mention host bits", err)
+			}
+		})
+	}
+}
+
+func TestStrictNetworkFlagModes(t *testing.T) {
+	for _, mode := range []string{"--json", "--ndjson", "--json-map", "--validate"} {
+		t.Run(mode, func(t *testing.T) {
+			var err error
+			captureStdout(t, func() {
+				err = runWithArgs([]string{"ripcalc", "--strict-network", mode, "10.0.0.0/24", "10.0.0.5/24"})
+			})
+
+			if !errors.Is(err, errStrictNetwork) {
+				t.Errorf("runWithArgs() error = %v, want %v", err, errStrictNetwork)
+			}
+
+			captureStdout(t, func() {
+				err = runWithArgs([]string{"ripcalc", "--strict-network", mode, "10.0.0.0/24", "2001:db8::/64"})
+			})
+
+			if err != nil {
+				t.Errorf("runWithArgs() with network addresses error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAnatomyFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"10.0.0.0/26", "    Prefix:\t26 network bits, 6 host bits; 64 addresses, 62 usable\n"},
+		{"10.0.0.0/31", "    Prefix:\t31 network bits, 1 host bits; 2 addresses, 2 usable\n"},
+		{"2001:db8::/120", "    Prefix:\t120 network bits, 8 host bits; 256 addresses, 256 usable\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--anatomy", tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if !strings.HasSuffix(output, tt.expected) {
+				t.Errorf("Output should end with %q, got:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestPreviewFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--preview", "3", "2001:db8::/64"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "  Preview:\t2001:db8::\n" +
+		"          \t2001:db8::1\n" +
+		"          \t2001:db8::2\n" +
+		"          \t...\n" +
+		"          \t2001:db8::ffff:ffff:ffff:fffd\n" +
+		"          \t2001:db8::ffff:ffff:ffff:fffe\n" +
+		"          \t2001:db8::ffff:ffff:ffff:ffff\n"
+	if !strings.HasSuffix(output, expected) {
+		t.Errorf("Output should end with %q, got:\n%s", expected, output)
+	}
+
+	for _, args := range [][]string{{"--preview", "3", "10.0.0.0/24"}, {"--preview", "-1", "2001:db8::/64"}} {
+		if err := runWithArgs(append([]string{"ripcalc"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestIGPFlags(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"--ospf", "10.0.0.0/24"}, "network 10.0.0.0 0.0.0.255 area 0\n"},
+		{[]string{"--ospf", "--area", "5", "10.0.0.77/24"}, "network 10.0.0.0 0.0.0.255 area 5\n"},
+		{[]string{"--eigrp", "10.0.0.0/24"}, "network 10.0.0.0 0.0.0.255\n"},
+		{[]string{"--ospf", "2001:db8::/64"}, "area 0 range 2001:db8::/64\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs(append([]string{"ripcalc"}, tt.args...)); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+
+	if err := runWithArgs([]string{"ripcalc", "--eigrp", "2001:db8::/64"}); err == nil {
+		t.Error("--eigrp should fail for IPv6 networks")
+	}
+}
+
+func TestOctetValuesFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected []string
+	}{
+		{"192.168.0.1/24", []string{
+			"    Places:\t 128  64  32  16   8   4   2   1\n",
+			"   Octet 2:\t   1   0   1   0   1   0   0   0 = 168\n",
+		}},
+		{"2001:db8::/64", []string{
+			"    Places:\t8421 8421 8421 8421\n",
+			"  Hextet 2:\t0000 1101 1011 1000 = 0x0db8 = 3512\n",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs([]string{"ripcalc", "--octet-values", tt.cidr}); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			for _, element := range tt.expected {
+				if !strings.Contains(output, element) {
+					t.Errorf("Output missing %q\nFull output:\n%s", element, output)
+				}
+			}
+		})
+	}
+}
+
+func TestBothCountsFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"10.0.0.0/24", "Usable hosts: 254\nTotal addresses: 256\n"},
+		{"10.0.0.0/31", "Usable (RFC 3021): 2, Total: 2\n"},
+		{"10.0.0.1/32", "Usable hosts: 1\nTotal addresses: 1\n"},
+		{"2001:db8::/120", "Usable hosts: 256\nTotal addresses: 256\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--no-binary", "--both-counts", tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if !strings.HasSuffix(output, tt.expected) {
+				t.Errorf("Output should end with %q\nFull output:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestParentFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		expected  string
+		wantError string
+	}{
+		{
+			name:     "last /26 of a /24",
+			args:     []string{"--parent", "192.168.1.0/24", "192.168.1.192/26"},
+			expected: "    Parent:\tsubnet 3 of 4 within 192.168.1.0/24 (counting from 0)\n",
+		},
+		{
+			name:      "outside parent",
+			args:      []string{"--parent", "192.168.1.0/24", "192.168.2.0/26"},
+			wantError: "not inside",
+		},
+		{
+			name:      "IPv6",
+			args:      []string{"--parent", "2001:db8::/32", "2001:db8::/48"},
+			wantError: "only supported for IPv4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+
+			output := captureStdout(t, func() {
+				err = runWithArgs(append([]string{"ripcalc", "--no-binary"}, tt.args...))
+			})
+
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("run() error = %v, want one containing %q", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("run() failed: %v", err)
+			}
+
+			if !strings.HasSuffix(output, tt.expected) {
+				t.Errorf("Output should end with %q\nFull output:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestSipcalcFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"192.168.1.0/24", "Usable range\t\t- 192.168.1.1 - 192.168.1.254\n"},
+		{"2001:db8::/64", "Address type\t\t- Aggregatable Global Unicast Addresses\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--sipcalc", tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if !strings.Contains(output, tt.expected) {
+				t.Errorf("Output should contain %q\nFull output:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestProvisionFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--provision", "26", "10.0.0.0/24"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "" +
+		"Subnet              Gateway          Broadcast\n" +
+		"10.0.0.0/26         10.0.0.1         10.0.0.63\n" +
+		"10.0.0.64/26        10.0.0.65        10.0.0.127\n" +
+		"10.0.0.128/26       10.0.0.129       10.0.0.191\n" +
+		"10.0.0.192/26       10.0.0.193       10.0.0.255\n"
+
+	if output != expected {
+		t.Errorf("Output =\n%s\nwant\n%s", output, expected)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--provision", "30", "10.0.0.0/8"})
+	if err == nil || !strings.Contains(err.Error(), "more than 4096") {
+		t.Errorf("Expected an error for too many subnets, got %v", err)
+	}
+}
+
+func TestList64Flag(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--list64", "--limit", "3", "2001:db8::/56"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "2001:db8::/64\n2001:db8:0:1::/64\n2001:db8:0:2::/64\n"
+	if output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	for _, args := range [][]string{
+		{"--list64", "2001:db8::/80"},
+		{"--list64", "--limit", "0", "2001:db8::/56"},
+		{"--list64", "10.0.0.0/24"},
+	} {
+		if err := runWithArgs(append([]string{"ripcalc"}, args...)); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestSpacesFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"IPv4 spaces", []string{"--spaces", "192.168.0.0/24"}, "   Network:     192.168.0.0/24"},
+		{"IPv4 tab size", []string{"--tabsize", "4", "192.168.0.0/24"}, "   Network: 192.168.0.0/24"},
+		{"IPv6 spaces", []string{"--spaces", "--ipv6-mask", "--advice", "2001:db8::/64"}, "    Advice:     /64 is the standard LAN size"},
+		{"parent", []string{"--spaces", "--parent", "10.0.0.0/24", "10.0.0.64/26"}, "    Parent:     subnet 1 of 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs(append([]string{"ripcalc"}, tt.args...)); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if strings.Contains(output, "\t") {
+				t.Errorf("Output contains tabs:\n%s", output)
+			}
+
+			if !strings.Contains(output, tt.expected) {
+				t.Errorf("Output missing %q:\n%s", tt.expected, output)
+			}
+		})
+	}
+
+	if err := runWithArgs([]string{"ripcalc", "--tabsize", "-1", "10.0.0.0/24"}); err == nil {
+		t.Error("Expected error for a negative --tabsize")
+	}
+}
+
+func TestRollUpFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--rollup", "10.0.1.0/24"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "  Supernet:\t10.0.0.0/23\n   Sibling:\t10.0.0.0/24\n"
+	if output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	for _, cidr := range []string{"0.0.0.0/0", "2001:db8::/32"} {
+		if err := runWithArgs([]string{"ripcalc", "--rollup", cidr}); err == nil {
+			t.Errorf("Expected an error for %s", cidr)
+		}
+	}
+}
+
+func TestBinaryOnlyFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"192.168.0.1/24", "11000000.10101000.00000000. 00000001\n"},
+		{"10.0.0.0/12", "00001010.0000 0000.00000000.00000000\n"},
+		{"2001:db8::1/32", "0010000000000001:0000110110111000: 0000000000000000:0000000000000000:" +
+			"0000000000000000:0000000000000000:0000000000000000:0000000000000001\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs([]string{"ripcalc", "--binary-only", tt.cidr}); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFingerprintFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"10.0.0.5/24", "a34d80f7\n"},
+		{"10.0.0.0/24", "a34d80f7\n"},
+		{"2001:db8::1/32", "90c2cbc2\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--fingerprint", tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExcludeFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--exclude", "10.0.0.64/26", "10.0.0.0/24"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "10.0.0.0/26\n10.0.0.128/25\n"
+	if output != expected {
+		t.Errorf("Output = %q, expected %q", output, expected)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--exclude", "10.0.1.0/26", "10.0.0.0/24"})
+	if err == nil {
+		t.Error("Expected error when excluded subnet is outside the network")
+	}
+}
+
+func TestHostsOrderFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "ascending by default",
+			args:     []string{"ripcalc", "--hosts", "10.0.0.0/29"},
+			expected: "10.0.0.1\n10.0.0.2\n10.0.0.3\n10.0.0.4\n10.0.0.5\n10.0.0.6\n",
+		},
+		{
+			name:     "descending",
+			args:     []string{"ripcalc", "--hosts", "--order", "desc", "10.0.0.0/29"},
+			expected: "10.0.0.6\n10.0.0.5\n10.0.0.4\n10.0.0.3\n10.0.0.2\n10.0.0.1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs(tt.args)
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, expected %q", output, tt.expected)
+			}
+		})
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--hosts", "--order", "random", "10.0.0.0/29"})
+	if err == nil {
+		t.Error("Expected error for unknown order")
+	}
+}
+
+func TestRecordFlag(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"192.168.0.1/24", "www.example.com. IN A 192.168.0.1\n"},
+		{"2001:db8::1/64", "www.example.com. IN AAAA 2001:db8::1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--record", "www.example.com", tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, expected %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReverseFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--reverse", "2001:db8:ab:cd00::/56"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "      Zone:\td.c.b.a.0.0.8.b.d.0.1.0.0.2.ip6.arpa.\nDelegation:\t/56\n"
+	if output != expected {
+		t.Errorf("Output = %q, expected %q", output, expected)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--reverse", "10.0.0.0/24"})
+	if err == nil {
+		t.Error("Expected error for --reverse with an IPv4 network")
+	}
+}
+
+func TestNDJSONFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--ndjson", "10.0.0.0/8", "192.168.1.0/24", "2001:db8::/64"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d:\n%s", len(lines), output)
+	}
+
+	expectedNetworks := []string{"10.0.0.0", "192.168.1.0", "2001:db8::"}
+
+	for i, line := range lines {
+		var doc struct {
+			Network struct {
+				Address string `json:"address"`
+			} `json:"network"`
+		}
+
+		if err := json.Unmarshal([]byte(line), &doc); err != nil {
+			t.Fatalf("Line %d is not a JSON object: %v\n%s", i+1, err, line)
+		}
+
+		if doc.Network.Address != expectedNetworks[i] {
+			t.Errorf("Line %d network = %q, expected %q", i+1, doc.Network.Address, expectedNetworks[i])
+		}
+	}
+}
+
+func TestJSONMapFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--json-map", "10.0.0.5/8", "2001:db8::/64", "10.0.0.0/33"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	var doc map[string]struct {
+		Error   string `json:"error"`
+		Network struct {
+			Address string `json:"address"`
+		} `json:"network"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("Output is not a JSON object: %v\n%s", err, output)
+	}
+
+	if len(doc) != 3 {
+		t.Fatalf("Expected 3 keys, got %d:\n%s", len(doc), output)
+	}
+
+	if got := doc["10.0.0.5/8"].Network.Address; got != "10.0.0.0" {
+		t.Errorf("10.0.0.5/8 network = %q, expected %q", got, "10.0.0.0")
+	}
+
+	if got := doc["2001:db8::/64"].Network.Address; got != "2001:db8::" {
+		t.Errorf("2001:db8::/64 network = %q, expected %q", got, "2001:db8::")
+	}
+
+	if got := doc["10.0.0.0/33"].Error; !strings.Contains(got, "invalid IPv4 CIDR notation") {
+		t.Errorf("10.0.0.0/33 error = %q, expected a parse error", got)
+	}
+}
+
+func TestFromJSONFlag(t *testing.T) {
+	tests := []struct {
+		name  string
+		flag  string
+		cidrs []string
+		extra []string
+	}{
+		{"single object", "--json", []string{"192.168.0.1/24"}, nil},
+		{"array", "--json", []string{"10.0.0.0/30", "2001:db8::/64"}, nil},
+		{"ndjson stream", "--ndjson", []string{"10.0.0.0/30", "2001:db8::/64"}, nil},
+		{"no class", "--json", []string{"8.8.8.0/24"}, []string{"--no-class"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := captureStdout(t, func() {
+				args := append(append([]string{"ripcalc", tt.flag}, tt.extra...), tt.cidrs...)
+				if err := runWithArgs(args); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			path := filepath.Join(t.TempDir(), "results.json")
+			if err := os.WriteFile(path, []byte(encoded), 0o600); err != nil {
+				t.Fatalf("os.WriteFile() error = %v", err)
+			}
+
+			output := captureStdout(t, func() {
+				if err := runWithArgs([]string{"ripcalc", "--from-json", "--file", path}); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			var expected []string
+			for _, cidr := range tt.cidrs {
+				expected = append(expected, captureStdout(t, func() {
+					if err := runWithArgs(append(append([]string{"ripcalc"}, tt.extra...), cidr)); err != nil {
+						t.Fatalf("run() failed: %v", err)
+					}
+				}))
+			}
+
+			if want := strings.Join(expected, "\n"); output != want {
+				t.Errorf("--from-json output =\n%s\nwant\n%s", output, want)
+			}
+		})
+	}
+}
+
+func TestJSONFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--json", "192.168.0.1/24"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "\n  \"address\": \"192.168.0.1\",\n") {
+		t.Errorf("Expected an indented JSON object, got:\n%s", output)
+	}
+
+	output = captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--json", "10.0.0.0/8", "2001:db8::/64"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	var docs []map[string]any
+	if err := json.Unmarshal([]byte(output), &docs); err != nil || len(docs) != 2 {
+		t.Errorf("Expected a JSON array of 2 objects, got %v:\n%s", err, output)
+	}
+}
+
+func TestJSONFlagEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--json", "--file", path}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if output != "[]\n" {
+		t.Errorf("Output = %q, want an empty array", output)
+	}
+}
+
+func TestIsDocFlag(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"192.0.2.10", false},
+		{"198.51.100.0/24", false},
+		{"203.0.113.1", false},
+		{"2001:db8::1", false},
+		{"8.8.8.8", true},
+		{"2001:4860::8888", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := runWithArgs([]string{"ripcalc", "--is-doc", tt.input})
+			if tt.wantErr && !errors.Is(err, errCheckFailed) {
+				t.Errorf("Expected errCheckFailed, got %v", err)
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected success, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIsGlobalFlag(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"8.8.8.8", false},
+		{"8.8.8.0/24", false},
+		{"2001:4860::8888", false},
+		{"10.0.0.1", true},
+		{"100.64.0.1", true},
+		{"240.0.0.1", true},
+		{"2001:db8::1", true},
+		{"::ffff:8.8.8.8", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			err := runWithArgs([]string{"ripcalc", "--is-global", tt.input})
+			if tt.wantErr && !errors.Is(err, errCheckFailed) {
+				t.Errorf("Expected errCheckFailed, got %v", err)
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected success, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIsGlobalFlagFamily(t *testing.T) {
+	if err := runWithArgs([]string{"ripcalc", "--family", "ipv4", "--is-global", "::ffff:8.8.8.8"}); err != nil {
+		t.Errorf("Expected --family ipv4 to check the embedded address, got %v", err)
+	}
+}
+
+func TestPlainFlag(t *testing.T) {
+	for _, cidr := range []string{"192.168.0.0/24", "2001:db8::/64"} {
+		t.Run(cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--plain", cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if strings.Contains(output, "\t") {
+				t.Errorf("Output contains tabs:\n%s", output)
+			}
+
+			if strings.Contains(output, "00000000") || strings.Contains(output, "11111111") {
+				t.Errorf("Output contains binary:\n%s", output)
+			}
+
+			if !strings.Contains(output, "   Network: ") {
+				t.Errorf("Output missing network field:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestBinaryWrapFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--ipv6-binary", "--binary-wrap", "2", "2001:db8::/64"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	// Each 8-group binary value is split over 4 lines
+	if got := strings.Count(output, "\t"+strings.Repeat(" ", 40)+"\t"); got != 4*3 {
+		t.Errorf("continuation lines = %d, expected 12\n%s", got, output)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--binary-wrap", "-1", "10.0.0.0/8"})
+	if err == nil {
+		t.Error("Expected error for negative --binary-wrap")
+	}
+}
+
+func TestFamilyFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--family", "ipv6", "2001:db8::/64"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "2001:db8::/64") {
+		t.Errorf("Output missing network:\n%s", output)
+	}
+
+	// Forcing IPv6 routes the mapped address to the IPv6 handler instead of the IPv4 one
+	output = captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--family", "ipv6", "::ffff:1.2.3.4/128"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "IPv4-mapped address for 1.2.3.4") {
+		t.Errorf("Output missing the embedded IPv4 address:\n%s", output)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--family", "ipv4", "2001:db8::/64"})
+	if err == nil || !strings.Contains(err.Error(), "invalid IPv4 CIDR notation") {
+		t.Errorf("Expected an IPv4 parse error, got %v", err)
+	}
+
+	err = runWithArgs([]string{"ripcalc", "--family", "ipx", "10.0.0.0/8"})
+	if err == nil {
+		t.Error("Expected error for unknown family")
+	}
+}
+
+func TestMagicFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--magic", "172.16.0.0/20"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "     Magic:\t16\n     Octet:\t3\n"
+	if output != expected {
+		t.Errorf("Output = %q, expected %q", output, expected)
+	}
+
+	output = captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--magic", "--tabsize", "4", "172.16.0.0/20"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected = "     Magic: 16\n     Octet: 3\n"
+	if output != expected {
+		t.Errorf("Output with --tabsize = %q, expected %q", output, expected)
+	}
+}
+
+func TestURLFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"--url", "2001:db8::1/64"}, "[2001:db8::1]\n"},
+		{[]string{"--url", "--port", "443", "2001:db8::1/64"}, "[2001:db8::1]:443\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs(append([]string{"ripcalc"}, tt.args...)); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+
+	if err := runWithArgs([]string{"ripcalc", "--url", "192.0.2.1/24"}); err == nil {
+		t.Error("--url should fail for IPv4 networks")
+	}
+}
+
+func TestForHostsFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--for-hosts", "500", "10.0.0.0"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "Network:\t10.0.0.0/23 ") {
+		t.Errorf("Output should contain the /23 network, got %q", output)
+	}
+
+	for _, args := range [][]string{
+		{"--for-hosts", "500", "10.0.1.0"},
+		{"--for-hosts", "500", "10.0.0.0/24"},
+		{"--for-hosts", "500", "2001:db8::"},
+		{"--for-hosts", "-1", "10.0.0.0"},
+	} {
+		if err := runWithArgs(append([]string{"ripcalc"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// errFound stops the list scan once a prefix containing the address has been found
+var errFound = errors.New("found")
+
+// handleMembership checks the --in or --not-in address against every CIDR argument and --file
+// line, printing the first prefix that contains it. With --in it succeeds only if a prefix
+// contains the address; with --not-in only if none does. Otherwise it returns errCheckFailed.
+func handleMembership(args []string, opts options) error {
+	address, wantIn := opts.in, true
+	if opts.notIn != "" {
+		address, wantIn = opts.notIn, false
+	}
+
+	ip, err := parseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 && opts.file == "" {
+		return fmt.Errorf("no CIDR list provided, pass CIDRs or --file")
+	}
+
+	err = eachInput(args, opts.file, func(line batchLine) error {
+		contains, err := listContains(line.cidr, ip, opts)
+		if err != nil {
+			return err
+		}
+
+		if contains {
+			fmt.Println(line.cidr)
+			return errFound
+		}
+
+		return nil
+	})
+
+	found := errors.Is(err, errFound)
+	if err != nil && !found {
+		return err
+	}
+
+	if found != wantIn {
+		return errCheckFailed
+	}
+
+	return nil
+}
+
+// listContains reports whether the list entry cidr contains ip. Entries of the other family never
+// contain it.
+func listContains(cidr string, ip net.IP, opts options) (bool, error) {
+	if opts.isIPv6(cidr) {
+		network, err := ipv6.ParseCIDR(cidr)
+		if err != nil {
+			return false, fmt.Errorf("invalid IPv6 CIDR notation %q: %w", cidr, err)
+		}
+
+		return ip.To4() == nil && network.Contains(ip), nil
+	}
+
+	network, err := ipv4.ParseCIDR(cidr)
+	if err != nil {
+		return false, fmt.Errorf("invalid IPv4 CIDR notation %q: %w", cidr, err)
+	}
+
+	return network.Contains(ip), nil
+}
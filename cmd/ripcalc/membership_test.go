@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMembershipFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.txt")
+	content := "# office ranges\n192.168.0.0/16\nlab 10.0.0.0/24\n2001:db8::/32\n"
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		flag      string
+		address   string
+		wantError error
+		expected  string
+	}{
+		{"in, inside", "--in", "10.0.0.5", nil, "10.0.0.0/24\n"},
+		{"in, outside", "--in", "10.0.1.5", errCheckFailed, ""},
+		{"in, IPv6 inside", "--in", "2001:db8::1", nil, "2001:db8::/32\n"},
+		{"not-in, inside", "--not-in", "10.0.0.5", errCheckFailed, "10.0.0.0/24\n"},
+		{"not-in, outside", "--not-in", "10.0.1.5", nil, ""},
+		{"not-in, IPv6 outside", "--not-in", "2001:db9::1", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+
+			output := captureStdout(t, func() {
+				err = runWithArgs([]string{"ripcalc", tt.flag, tt.address, "--file", path})
+			})
+
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("runWithArgs() error = %v, want %v", err, tt.wantError)
+			}
+
+			if output != tt.expected {
+				t.Errorf("Output = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
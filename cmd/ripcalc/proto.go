@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+	"github.com/ronny/ripcalc/wire"
+)
+
+// wireRecord parses and calculates cidr in its family and converts the result to a wire record
+func wireRecord(cidr string, opts options) (wire.Record, error) {
+	network, err := calculateNetwork(cidr, opts)
+	if err != nil {
+		return wire.Record{}, err
+	}
+
+	switch network := network.(type) {
+	case *ipv4.Network:
+		return wire.Record{
+			Family:       4,
+			Address:      network.Address,
+			PrefixLength: network.PrefixLength,
+			Network:      network.Network,
+			HostMin:      network.HostMin,
+			HostMax:      network.HostMax,
+			HostCount:    new(big.Int).SetUint64(uint64(network.HostCount)),
+			Class:        network.Class,
+			Type:         network.Type,
+		}, nil
+	case *ipv6.Network:
+		return wire.Record{
+			Family:       6,
+			Address:      network.Address,
+			PrefixLength: network.PrefixLength,
+			Network:      network.Network,
+			HostMin:      network.HostMin,
+			HostMax:      network.HostMax,
+			HostCount:    network.HostCount,
+			Class:        network.Class,
+			Type:         network.Type,
+		}, nil
+	default:
+		return wire.Record{}, fmt.Errorf("unexpected network type %T", network)
+	}
+}
+
+// handleProto writes every input to standard output as a length-prefixed binary message in the
+// format documented in the wire package, as each is calculated
+func handleProto(args []string, opts options) (err error) {
+	if len(args) == 0 && opts.file == "" {
+		printUsage()
+		return fmt.Errorf("no CIDR argument provided")
+	}
+
+	output := bufio.NewWriter(os.Stdout)
+
+	defer func() {
+		if flushErr := output.Flush(); flushErr != nil && err == nil {
+			err = fmt.Errorf("output.Flush: %w", flushErr)
+		}
+	}()
+
+	writer := wire.NewWriter(output)
+
+	return eachInput(args, opts.file, func(line batchLine) error {
+		record, err := wireRecord(line.cidr, opts)
+		if err != nil {
+			return err
+		}
+
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("writer.Write: %w", err)
+		}
+
+		return nil
+	})
+}
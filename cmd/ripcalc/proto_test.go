@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/wire"
+)
+
+func TestProtoFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--proto", "192.168.0.1/24", "2001:db8::1/64"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	reader := wire.NewReader(strings.NewReader(output))
+
+	tests := []struct {
+		network   string
+		hostCount string
+		typ       string
+	}{
+		{"192.168.0.0", "254", "Private Internet"},
+		{"2001:db8::", "18446744073709551616", "RFC Example"},
+	}
+
+	for _, tt := range tests {
+		record, err := reader.Read()
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+
+		if record.Network.String() != tt.network || record.HostCount.String() != tt.hostCount || record.Type != tt.typ {
+			t.Errorf("Read() = %s, %s, %q, want %s, %s, %q",
+				record.Network, record.HostCount, record.Type, tt.network, tt.hostCount, tt.typ)
+		}
+	}
+
+	if _, err := reader.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Read() at end error = %v, want io.EOF", err)
+	}
+}
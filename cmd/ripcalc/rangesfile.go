@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// customRanges holds the --ranges-file entries split by family
+type customRanges struct {
+	ipv4 []ipv4.CustomRange
+	ipv6 []ipv6.CustomRange
+}
+
+// parseRangesLine splits a ranges file line into the network and its label, e.g.
+// "100.127.0.0/16 Corp DMZ". Blank lines and lines starting with # are skipped.
+func parseRangesLine(line string) (*net.IPNet, string, bool, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, "", false, nil
+	}
+
+	cidr, label, _ := strings.Cut(strings.Join(strings.Fields(line), " "), " ")
+
+	if label == "" {
+		return nil, "", false, fmt.Errorf("missing label for %s", cidr)
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("net.ParseCIDR: %w", err)
+	}
+
+	return network, label, true, nil
+}
+
+// loadRangesFile reads the custom classification ranges from the file at path (- for stdin)
+func loadRangesFile(path string) (ranges customRanges, err error) {
+	input, err := openBatchInput(path)
+	if err != nil {
+		return customRanges{}, err
+	}
+
+	defer func() {
+		if closeErr := input.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("input.Close: %w", closeErr)
+		}
+	}()
+
+	scanner := bufio.NewScanner(input)
+	number := 0
+
+	for scanner.Scan() {
+		number++
+
+		network, label, ok, err := parseRangesLine(scanner.Text())
+		if err != nil {
+			return customRanges{}, fmt.Errorf("%s line %d: %w", path, number, err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if network.IP.To4() != nil {
+			ranges.ipv4 = append(ranges.ipv4, ipv4.CustomRange{Network: network, Label: label})
+		} else {
+			ranges.ipv6 = append(ranges.ipv6, ipv6.CustomRange{Network: network, Label: label})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return customRanges{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRangesFileFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.txt")
+	content := "# corporate ranges\n100.127.0.0/16 Corp DMZ\n2001:db8:ca7::/48\tLab\n"
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		cidr     string
+		expected string
+	}{
+		{"100.127.4.0/24", "Class A, Corp DMZ"},
+		{"100.64.0.0/24", "Class A, Shared Address Space"},
+		{"2001:db8:ca7:1::/64", "Documentation, Lab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				err := runWithArgs([]string{"ripcalc", "--ranges-file", path, tt.cidr})
+				if err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			if !strings.Contains(output, tt.expected) {
+				t.Errorf("Output missing %q\nFull output:\n%s", tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestRangesFileInvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.txt")
+
+	if err := os.WriteFile(path, []byte("10.0.0.0/8 Corp\n10.1.0.0/16\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--ranges-file", path, "10.0.0.0/8"})
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error naming line 2, got %v", err)
+	}
+}
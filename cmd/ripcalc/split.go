@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+// splitCSVHeader names the columns of --split --csv, one row per subnet
+var splitCSVHeader = []string{"subnet", "netmask", "network", "broadcast", "hostmin", "hostmax", "hosts"}
+
+// printSplit prints the /newPrefix subnets of network one per line, or as CSV with a header row
+// and each subnet's addresses for allocation spreadsheets. The host columns use FirstUsable and
+// LastUsable, so /31 (RFC 3021) and /32 rows list every address as usable.
+func printSplit(network *ipv4.Network, newPrefix int, asCSV bool) error {
+	subnets, err := network.Split(newPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to split %s into /%d subnets: %w", network, newPrefix, err)
+	}
+
+	if !asCSV {
+		for _, subnet := range subnets {
+			fmt.Println(subnet)
+		}
+
+		return nil
+	}
+
+	w := csv.NewWriter(os.Stdout)
+
+	if err := w.Write(splitCSVHeader); err != nil {
+		return fmt.Errorf("csv.Writer.Write: %w", err)
+	}
+
+	for _, subnet := range subnets {
+		err := w.Write([]string{
+			subnet.String(),
+			net.IP(subnet.Netmask).String(),
+			subnet.Network.String(),
+			subnet.Broadcast.String(),
+			subnet.FirstUsable().String(),
+			subnet.LastUsable().String(),
+			strconv.FormatUint(subnet.UsableHosts(), 10),
+		})
+		if err != nil {
+			return fmt.Errorf("csv.Writer.Write: %w", err)
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("csv.Writer.Flush: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitCSVFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--split", "26", "--csv", "10.0.0.0/24"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := []string{
+		"subnet,netmask,network,broadcast,hostmin,hostmax,hosts",
+		"10.0.0.0/26,255.255.255.192,10.0.0.0,10.0.0.63,10.0.0.1,10.0.0.62,62",
+		"10.0.0.64/26,255.255.255.192,10.0.0.64,10.0.0.127,10.0.0.65,10.0.0.126,62",
+		"10.0.0.128/26,255.255.255.192,10.0.0.128,10.0.0.191,10.0.0.129,10.0.0.190,62",
+		"10.0.0.192/26,255.255.255.192,10.0.0.192,10.0.0.255,10.0.0.193,10.0.0.254,62",
+	}
+
+	if want := strings.Join(expected, "\n") + "\n"; output != want {
+		t.Errorf("Output =\n%s\nwant\n%s", output, want)
+	}
+}
+
+func TestSplitCSVPointToPoint(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"--split", "31", "--csv", "10.0.0.0/30"}, []string{
+			"10.0.0.0/31,255.255.255.254,10.0.0.0,10.0.0.1,10.0.0.0,10.0.0.1,2",
+			"10.0.0.2/31,255.255.255.254,10.0.0.2,10.0.0.3,10.0.0.2,10.0.0.3,2",
+		}},
+		{[]string{"--split", "32", "--csv", "10.0.0.0/31"}, []string{
+			"10.0.0.0/32,255.255.255.255,10.0.0.0,10.0.0.0,10.0.0.0,10.0.0.0,1",
+			"10.0.0.1/32,255.255.255.255,10.0.0.1,10.0.0.1,10.0.0.1,10.0.0.1,1",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs(append([]string{"ripcalc"}, tt.args...)); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			want := strings.Join(append([]string{strings.Join(splitCSVHeader, ",")}, tt.expected...), "\n") + "\n"
+			if output != want {
+				t.Errorf("Output =\n%s\nwant\n%s", output, want)
+			}
+		})
+	}
+}
+
+func TestSplitFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		if err := runWithArgs([]string{"ripcalc", "--split", "25", "10.0.0.0/24"}); err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "10.0.0.0/25\n10.0.0.128/25\n"
+	if output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	for _, args := range [][]string{
+		{"--split", "23", "10.0.0.0/24"},
+		{"--split", "64", "2001:db8::/48"},
+		{"--csv", "10.0.0.0/24"},
+	} {
+		if err := runWithArgs(append([]string{"ripcalc"}, args...)); err == nil {
+			t.Errorf("runWithArgs(%v) should fail", args)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// handleSweep prints the usual output for the address at every prefix length from --from to --to,
+// anchored at the one address, to show how the boundaries and host counts change as the mask
+// tightens. A --to of -1 sweeps up to the longest prefix for the family.
+func handleSweep(address string, opts options) error {
+	ip, err := parseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	maxPrefix := 32
+	if opts.isIPv6(address) {
+		maxPrefix = 128
+	}
+
+	to := opts.sweepTo
+	if to == -1 {
+		to = maxPrefix
+	}
+
+	if opts.sweepFrom < 0 || opts.sweepFrom > maxPrefix || to < 0 || to > maxPrefix {
+		return fmt.Errorf("invalid sweep /%d to /%d, expected prefixes within /0 to /%d", opts.sweepFrom, to, maxPrefix)
+	}
+
+	if opts.sweepFrom > to {
+		return fmt.Errorf("invalid sweep /%d to /%d, --from is after --to", opts.sweepFrom, to)
+	}
+
+	for prefix := opts.sweepFrom; prefix <= to; prefix++ {
+		if prefix != opts.sweepFrom {
+			fmt.Println()
+		}
+
+		if err := handleInput(fmt.Sprintf("%s/%d", ip, prefix), opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// coversNibbleStep is the prefix step --covers uses for IPv6, printing the nibble boundaries
+// rather than all 129 prefix lengths
+const coversNibbleStep = 4
+
+// handleCovers prints the network containing the address at every prefix length, one CIDR per
+// line from /0 to the host route, to show the routing hierarchy it sits in. IPv6 steps by nibble.
+func handleCovers(address string, opts options) error {
+	ip, err := parseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	if opts.isIPv6(address) {
+		networks, err := ipv6.CoveringPrefixes(ip, coversNibbleStep)
+		if err != nil {
+			return fmt.Errorf("ipv6.CoveringPrefixes: %w", err)
+		}
+
+		for _, network := range networks {
+			fmt.Println(network)
+		}
+
+		return nil
+	}
+
+	networks, err := ipv4.CoveringPrefixes(ip)
+	if err != nil {
+		return fmt.Errorf("ipv4.CoveringPrefixes: %w", err)
+	}
+
+	for _, network := range networks {
+		fmt.Println(network)
+	}
+
+	return nil
+}
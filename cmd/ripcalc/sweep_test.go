@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSweepFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--no-binary", "--sweep", "10.0.0.0", "--from", "24", "--to", "28"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if got := strings.Count(output, "   Network:\t"); got != 5 {
+		t.Errorf("Output has %d blocks, want 5\nFull output:\n%s", got, output)
+	}
+
+	for _, element := range []string{"10.0.0.0/24", "10.0.0.0/25", "10.0.0.0/26", "10.0.0.0/27", "10.0.0.0/28"} {
+		if !strings.Contains(output, element) {
+			t.Errorf("Output missing %q\nFull output:\n%s", element, output)
+		}
+	}
+}
+
+func TestSweepFlagInvalidRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"from after to", []string{"--sweep", "10.0.0.0", "--from", "28", "--to", "24"}, "--from is after --to"},
+		{"beyond IPv4", []string{"--sweep", "10.0.0.0", "--from", "24", "--to", "33"}, "within /0 to /32"},
+		{"negative", []string{"--sweep", "2001:db8::", "--from", "-1", "--to", "64"}, "within /0 to /128"},
+		{"bad address", []string{"--sweep", "10.0.0", "--from", "24", "--to", "28"}, "invalid IP address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runWithArgs(append([]string{"ripcalc"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("runWithArgs() error = %v, want %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCoversFlag(t *testing.T) {
+	tests := []struct {
+		address   string
+		wantLines int
+		wantLine  map[int]string
+	}{
+		{"10.0.0.5", 33, map[int]string{0: "0.0.0.0/0", 8: "10.0.0.0/8", 16: "10.0.0.0/16", 24: "10.0.0.0/24", 32: "10.0.0.5/32"}},
+		{"2001:db8::1", 33, map[int]string{0: "::/0", 8: "2001:db8::/32", 16: "2001:db8::/64", 32: "2001:db8::1/128"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			output := captureStdout(t, func() {
+				if err := runWithArgs([]string{"ripcalc", "--covers", tt.address}); err != nil {
+					t.Fatalf("run() failed: %v", err)
+				}
+			})
+
+			lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+			if len(lines) != tt.wantLines {
+				t.Fatalf("Output has %d lines, want %d\n%s", len(lines), tt.wantLines, output)
+			}
+
+			for i, want := range tt.wantLine {
+				if lines[i] != want {
+					t.Errorf("line %d = %q, want %q", i, lines[i], want)
+				}
+			}
+		})
+	}
+
+	if err := runWithArgs([]string{"ripcalc", "--covers", "10.0.0.256"}); err == nil {
+		t.Error("--covers should fail for an invalid address")
+	}
+}
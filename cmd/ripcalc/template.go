@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/ronny/ripcalc"
+)
+
+// handleTemplate renders the --template text for every CIDR argument and --file line, one result
+// per line, against the fields of ripcalc.TemplateData. The template is parsed before any input is
+// read, so a syntax error is reported straight away.
+func handleTemplate(args []string, opts options) error {
+	tmpl, err := template.New("--template").Parse(opts.template)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+
+	if len(args) == 0 && opts.file == "" {
+		printUsage()
+		return fmt.Errorf("no CIDR argument provided")
+	}
+
+	return eachInput(args, opts.file, func(line batchLine) error {
+		network, err := calculateNetwork(line.cidr, opts)
+		if err != nil {
+			return err
+		}
+
+		info, ok := network.(ripcalc.NetworkInfo)
+		if !ok {
+			return fmt.Errorf("%w: %q has no template fields", ripcalc.ErrInvalidAddress, line.cidr)
+		}
+
+		data, err := ripcalc.NewTemplateData(info)
+		if err != nil {
+			return fmt.Errorf("ripcalc.NewTemplateData: %w", err)
+		}
+
+		if err := tmpl.Execute(os.Stdout, data); err != nil {
+			return fmt.Errorf("--template: %w", err)
+		}
+
+		fmt.Println()
+
+		return nil
+	})
+}
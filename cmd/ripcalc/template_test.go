@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTemplateFlag(t *testing.T) {
+	output := captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--template", "{{.Network}}/{{.Prefix}} has {{.HostCount}} hosts", "10.0.0.0/24"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	expected := "10.0.0.0/24 has 254 hosts\n"
+	if output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	output = captureStdout(t, func() {
+		err := runWithArgs([]string{"ripcalc", "--template", "{{.FirstHost}}-{{.LastHost}} {{.HostCount}}/{{.TotalAddresses}}", "10.0.0.0/31"})
+		if err != nil {
+			t.Fatalf("run() failed: %v", err)
+		}
+	})
+
+	if expected := "10.0.0.0-10.0.0.1 2/2\n"; output != expected {
+		t.Errorf("Output = %q, want %q", output, expected)
+	}
+
+	for _, tmpl := range []string{"{{.Network", "{{.NoSuchField}}"} {
+		if err := runWithArgs([]string{"ripcalc", "--template", tmpl, "10.0.0.0/24"}); err == nil {
+			t.Errorf("--template %q should fail", tmpl)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// handleValidate checks that every CIDR argument and --file line parses as a CIDR of its detected
+// (or --family) family, printing nothing when they all do. It stops at the first invalid input and
+// returns its parse error. Nothing is calculated, so large lists are checked quickly, unless
+// --strict-network also rejects addresses with host bits set.
+func handleValidate(args []string, opts options) error {
+	if len(args) == 0 && opts.file == "" {
+		return fmt.Errorf("no CIDR argument provided")
+	}
+
+	return eachInput(args, opts.file, func(line batchLine) error {
+		return validateCIDR(line.cidr, opts)
+	})
+}
+
+// validateCIDR parses cidr with its family's parser without calculating the network, unless
+// --strict-network needs it calculated to check for host bits
+func validateCIDR(cidr string, opts options) error {
+	if opts.strictNet {
+		_, err := calculateNetwork(cidr, opts)
+		return err
+	}
+
+	if opts.isIPv6(cidr) {
+		if _, err := ipv6.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid IPv6 CIDR notation %q: %w", cidr, err)
+		}
+
+		return nil
+	}
+
+	if _, err := ipv4.ParseCIDR(cidr); err != nil {
+		return fmt.Errorf("invalid IPv4 CIDR notation %q: %w", cidr, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantError string
+	}{
+		{"valid IPv4", []string{"10.0.0.0/24"}, ""},
+		{"valid IPv6", []string{"2001:db8::/32"}, ""},
+		{"several valid", []string{"10.0.0.0/8", "192.168/16", "2001:db8::1/64"}, ""},
+		{"missing prefix", []string{"10.0.0.0"}, "invalid IPv4 CIDR notation"},
+		{"prefix too long", []string{"10.0.0.0/33"}, "invalid IPv4 CIDR notation"},
+		{"bad IPv6", []string{"2001:db8::g/64"}, "\"2001:db8::g/64\""},
+		{"IPv6 prefix too long", []string{"2001:db8::/129"}, "2001:db8::/129"},
+		{"one invalid among valid", []string{"10.0.0.0/24", "300.0.0.0/8"}, "\"300.0.0.0/8\""},
+		{"wrong family", []string{"--family", "ipv4", "2001:db8::/32"}, "invalid IPv4 CIDR notation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+
+			output := captureStdout(t, func() {
+				err = runWithArgs(append([]string{"ripcalc", "--validate"}, tt.args...))
+			})
+
+			if output != "" {
+				t.Errorf("Output = %q, want none", output)
+			}
+
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("run() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("run() error = %v, want one containing %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestValidateFlag_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefixes.txt")
+	if err := os.WriteFile(path, []byte("web 10.0.1.0/24\n# spare\n10.0.2.0/240\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	err := runWithArgs([]string{"ripcalc", "--validate", "--file", path})
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("run() error = %v, want one for line 3", err)
+	}
+}
@@ -0,0 +1,133 @@
+// Package layout renders the labelled text blocks shared by the ipv4 and ipv6 formatters, so both
+// families line up the same way.
+package layout
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SeparatorPlaceholder marks the line in formatted text that DrawSeparator replaces with dashes
+const SeparatorPlaceholder = "\x00"
+
+// Row is one labelled line of a text block. Extra, if set, is printed in a second column after
+// the value, e.g. the class and type after the host count.
+type Row struct {
+	Label string
+	Value string
+	Extra string
+}
+
+// Separator is the row rendered as the dashed line between the input and the network fields
+var Separator = Row{}
+
+// Rows renders the rows with right-aligned labels and tab-separated values, padding the values to
+// the widest one only where a second column follows, then appends trailer (such as notes) and
+// draws the separator across the whole block
+func Rows(rows []Row, trailer string) string {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row.Value))
+	}
+
+	lines := make([]string, 0, len(rows))
+
+	for _, row := range rows {
+		switch {
+		case row == Separator:
+			lines = append(lines, SeparatorPlaceholder)
+		case row.Extra != "":
+			lines = append(lines, fmt.Sprintf("%10s:\t%-*s\t%s", row.Label, width, row.Value, row.Extra))
+		default:
+			lines = append(lines, fmt.Sprintf("%10s:\t%s", row.Label, row.Value))
+		}
+	}
+
+	return DrawSeparator(strings.Join(lines, "\n") + trailer)
+}
+
+// DrawSeparator replaces the separator placeholder line with dashes as wide as the longest
+// rendered line, so the separator always matches the content it divides
+func DrawSeparator(text string) string {
+	lines := strings.Split(text, "\n")
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, DisplayWidth(line))
+	}
+
+	for i, line := range lines {
+		if line == SeparatorPlaceholder {
+			lines[i] = strings.Repeat("-", width)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// DisplayWidth returns the number of columns a line occupies on a terminal with 8-column tab
+// stops, ignoring trailing padding
+func DisplayWidth(line string) int {
+	width := 0
+
+	for _, r := range strings.TrimRight(line, " ") {
+		if r == '\t' {
+			width += 8 - width%8
+		} else {
+			width++
+		}
+	}
+
+	return width
+}
+
+// Plain converts tab-aligned formatted text to space-aligned text and redraws the separator to the
+// new width
+func Plain(text string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if line != "" && strings.Trim(line, "-") == "" {
+			lines[i] = SeparatorPlaceholder
+			continue
+		}
+
+		label, value, _ := strings.Cut(line, "\t")
+		lines[i] = strings.TrimRight(label+" "+strings.ReplaceAll(value, "\t", "  "), " ")
+	}
+
+	return DrawSeparator(strings.Join(lines, "\n"))
+}
+
+// ExpandTabs replaces each tab with the spaces up to the next multiple of tabSize columns, so the
+// text lines up the same in every viewer, and redraws the separator to the new width
+func ExpandTabs(text string, tabSize int) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if line != "" && strings.Trim(line, "-") == "" {
+			lines[i] = SeparatorPlaceholder
+			continue
+		}
+
+		var b strings.Builder
+
+		column := 0
+		for _, r := range line {
+			if r != '\t' {
+				b.WriteRune(r)
+				column++
+
+				continue
+			}
+
+			spaces := tabSize - column%tabSize
+			b.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		}
+
+		lines[i] = strings.TrimRight(b.String(), " ")
+	}
+
+	return DrawSeparator(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,57 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/internal/layout"
+)
+
+func TestRows(t *testing.T) {
+	output := layout.Rows([]layout.Row{
+		{Label: "Address", Value: "10.0.0.1"},
+		layout.Separator,
+		{Label: "Network", Value: "10.0.0.0/8"},
+		{Label: "Host count", Value: "16777214", Extra: "Class A"},
+	}, "\n      Note:\tnote")
+
+	expected := "" +
+		"   Address:\t10.0.0.1\n" +
+		strings.Repeat("-", 39) + "\n" +
+		"   Network:\t10.0.0.0/8\n" +
+		"Host count:\t16777214  \tClass A\n" +
+		"      Note:\tnote"
+
+	if output != expected {
+		t.Errorf("Rows() =\n%q\nwant\n%q", output, expected)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	output := layout.Plain("   Address:\t10.0.0.1\n--------------------------\nHost count:\t1  \tClass A")
+
+	expected := "   Address: 10.0.0.1\n" + strings.Repeat("-", 24) + "\nHost count: 1    Class A"
+
+	if output != expected {
+		t.Errorf("Plain() =\n%q\nwant\n%q", output, expected)
+	}
+}
+
+func TestExpandTabs(t *testing.T) {
+	input := "   Address:\t10.0.0.1\n--------------------------\nHost count:\t1  \tClass A"
+
+	tests := []struct {
+		tabSize  int
+		expected string
+	}{
+		{8, "   Address:     10.0.0.1\n" + strings.Repeat("-", 31) + "\nHost count:     1       Class A"},
+		{4, "   Address: 10.0.0.1\n" + strings.Repeat("-", 23) + "\nHost count: 1   Class A"},
+	}
+
+	for _, tt := range tests {
+		output := layout.ExpandTabs(input, tt.tabSize)
+		if output != tt.expected {
+			t.Errorf("ExpandTabs(%d) =\n%q\nwant\n%q", tt.tabSize, output, tt.expected)
+		}
+	}
+}
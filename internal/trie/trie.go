@@ -0,0 +1,67 @@
+// Package trie provides a binary prefix trie for longest-prefix-match lookups, so the ipv4 and
+// ipv6 classifiers don't scan every special range for each address.
+package trie
+
+import "net"
+
+type node[V any] struct {
+	children [2]*node[V]
+	value    V
+	ok       bool
+}
+
+// Trie maps address prefixes to values. The zero value is an empty trie ready to use.
+type Trie[V any] struct {
+	root node[V]
+}
+
+// Insert stores value for the first prefixLen bits of addr. A value already stored for the same
+// prefix is kept, so inserting ranges in priority order gives the same result as a first-match
+// scan over them.
+func (t *Trie[V]) Insert(addr []byte, prefixLen int, value V) {
+	n := &t.root
+
+	for i := range prefixLen {
+		bit := bitAt(addr, i)
+		if n.children[bit] == nil {
+			n.children[bit] = &node[V]{}
+		}
+
+		n = n.children[bit]
+	}
+
+	if !n.ok {
+		n.value, n.ok = value, true
+	}
+}
+
+// InsertNet stores value for the network's prefix, as Insert does
+func (t *Trie[V]) InsertNet(network *net.IPNet, value V) {
+	ones, _ := network.Mask.Size()
+	t.Insert(network.IP, ones, value)
+}
+
+// Lookup returns the value of the longest stored prefix containing addr, and whether there was
+// one. addr must have the same length as the inserted addresses.
+func (t *Trie[V]) Lookup(addr []byte) (V, bool) {
+	n := &t.root
+	value, ok := n.value, n.ok
+
+	for i := range len(addr) * 8 {
+		n = n.children[bitAt(addr, i)]
+		if n == nil {
+			break
+		}
+
+		if n.ok {
+			value, ok = n.value, true
+		}
+	}
+
+	return value, ok
+}
+
+// bitAt returns bit i of addr, counting from the most significant bit of the first byte
+func bitAt(addr []byte, i int) int {
+	return int(addr[i/8]>>(7-i%8)) & 1
+}
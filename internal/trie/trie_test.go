@@ -0,0 +1,133 @@
+package trie_test
+
+import (
+	"math/rand/v2"
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/internal/trie"
+)
+
+func TestTrie_Lookup(t *testing.T) {
+	var tr trie.Trie[string]
+
+	for _, entry := range []struct{ cidr, value string }{
+		{"2001:db8::/32", "documentation"},
+		{"2001::/32", "teredo"},
+		{"2000::/3", "global"},
+		{"2000::/3", "duplicate ignored"},
+		{"::/128", "unspecified"},
+	} {
+		_, network, err := net.ParseCIDR(entry.cidr)
+		if err != nil {
+			t.Fatalf("net.ParseCIDR() error = %v", err)
+		}
+
+		tr.InsertNet(network, entry.value)
+	}
+
+	tests := []struct {
+		ip     string
+		want   string
+		wantOK bool
+	}{
+		{"2001:db8::1", "documentation", true},
+		{"2001::1", "teredo", true},
+		{"2a00::1", "global", true},
+		{"::", "unspecified", true},
+		{"::1", "", false},
+		{"fe80::1", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			got, ok := tr.Lookup(net.ParseIP(tt.ip).To16())
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Lookup() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestTrie_MatchesSpecificityOrderedScan checks the trie against a first-match scan over ranges
+// sorted most specific first, the order the classifiers' range tables are kept in
+func TestTrie_MatchesSpecificityOrderedScan(t *testing.T) {
+	ranges := randomRanges(rand.New(rand.NewPCG(1, 2)), 200)
+
+	var tr trie.Trie[int]
+	for i, network := range ranges {
+		tr.InsertNet(network, i)
+	}
+
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	for range 10000 {
+		addr := randomAddr(rng)
+
+		want, wantOK := linearLookup(ranges, addr)
+		if got, ok := tr.Lookup(addr); got != want || ok != wantOK {
+			t.Fatalf("Lookup(%s) = %d, %v, want %d, %v", addr, got, ok, want, wantOK)
+		}
+	}
+}
+
+func BenchmarkLookup(b *testing.B) {
+	ranges := randomRanges(rand.New(rand.NewPCG(1, 2)), 50)
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	addrs := make([]net.IP, 100000)
+	for i := range addrs {
+		addrs[i] = randomAddr(rng)
+	}
+
+	var tr trie.Trie[int]
+	for i, network := range ranges {
+		tr.InsertNet(network, i)
+	}
+
+	b.Run("trie", func(b *testing.B) {
+		for b.Loop() {
+			for _, addr := range addrs {
+				tr.Lookup(addr)
+			}
+		}
+	})
+
+	b.Run("linear", func(b *testing.B) {
+		for b.Loop() {
+			for _, addr := range addrs {
+				linearLookup(ranges, addr)
+			}
+		}
+	})
+}
+
+// randomRanges returns IPv4 networks sorted by descending prefix length, with prefixes short
+// enough that random addresses often fall into nested ranges
+func randomRanges(rng *rand.Rand, count int) []*net.IPNet {
+	ranges := make([]*net.IPNet, 0, count)
+
+	for prefix := 24; prefix >= 1 && len(ranges) < count; prefix-- {
+		for range count / 24 {
+			addr := randomAddr(rng)
+			ranges = append(ranges, &net.IPNet{IP: addr.Mask(net.CIDRMask(prefix, 32)), Mask: net.CIDRMask(prefix, 32)})
+		}
+	}
+
+	return ranges
+}
+
+func randomAddr(rng *rand.Rand) net.IP {
+	v := rng.Uint32()
+	return net.IP{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+}
+
+func linearLookup(ranges []*net.IPNet, addr net.IP) (int, bool) {
+	for i, network := range ranges {
+		if network.Contains(addr) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
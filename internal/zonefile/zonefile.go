@@ -0,0 +1,24 @@
+// Package zonefile renders the BIND-style reverse zone header shared by the ipv4 and ipv6 zone
+// file templates, so both families use the same placeholder SOA.
+package zonefile
+
+import "fmt"
+
+// soaTimers holds the placeholder SOA serial and timer values
+const soaTimers = "" +
+	"\t\t\t1\t; serial\n" +
+	"\t\t\t3600\t; refresh\n" +
+	"\t\t\t900\t; retry\n" +
+	"\t\t\t604800\t; expire\n" +
+	"\t\t\t3600 )\t; minimum\n"
+
+// Header returns the $ORIGIN and $TTL directives, a placeholder SOA and an NS record for a reverse
+// zone. origin is the zone's fully qualified name and domain the fully qualified domain of the
+// name server and hostmaster, both with a trailing dot.
+func Header(origin, domain string) string {
+	return fmt.Sprintf("$ORIGIN %s\n", origin) +
+		"$TTL 3600\n" +
+		fmt.Sprintf("@\tIN\tSOA\tns1.%s hostmaster.%s (\n", domain, domain) +
+		soaTimers +
+		fmt.Sprintf("@\tIN\tNS\tns1.%s\n", domain)
+}
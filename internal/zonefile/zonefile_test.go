@@ -0,0 +1,23 @@
+package zonefile_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/internal/zonefile"
+)
+
+func TestHeader(t *testing.T) {
+	expected := "$ORIGIN 2.0.192.in-addr.arpa.\n" +
+		"$TTL 3600\n" +
+		"@\tIN\tSOA\tns1.example.com. hostmaster.example.com. (\n" +
+		"\t\t\t1\t; serial\n" +
+		"\t\t\t3600\t; refresh\n" +
+		"\t\t\t900\t; retry\n" +
+		"\t\t\t604800\t; expire\n" +
+		"\t\t\t3600 )\t; minimum\n" +
+		"@\tIN\tNS\tns1.example.com.\n"
+
+	if got := zonefile.Header("2.0.192.in-addr.arpa.", "example.com."); got != expected {
+		t.Errorf("Header() =\n%s\nwant\n%s", got, expected)
+	}
+}
@@ -0,0 +1,38 @@
+package ipv4
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NormalizeAbbreviated expands abbreviated IPv4 CIDR notation where trailing octets are omitted,
+// e.g. "10/8" becomes "10.0.0.0/8" and "192.168/16" becomes "192.168.0.0/16". Missing octets
+// are zero-filled. Input that already has four octets is returned unchanged.
+func NormalizeAbbreviated(s string) (string, error) {
+	addr, prefix, found := strings.Cut(s, "/")
+	if !found {
+		return "", fmt.Errorf("%w: missing prefix length in %q", ErrInvalidAddress, s)
+	}
+
+	octets := strings.Split(addr, ".")
+	if len(octets) == 4 {
+		return s, nil
+	}
+
+	if len(octets) > 4 {
+		return "", fmt.Errorf("%w: too many octets in %q", ErrInvalidAddress, s)
+	}
+
+	for _, octet := range octets {
+		if _, err := strconv.ParseUint(octet, 10, 8); err != nil {
+			return "", fmt.Errorf("%w: invalid octet %q in %q", ErrInvalidAddress, octet, s)
+		}
+	}
+
+	for len(octets) < 4 {
+		octets = append(octets, "0")
+	}
+
+	return strings.Join(octets, ".") + "/" + prefix, nil
+}
@@ -0,0 +1,94 @@
+package ipv4_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNormalizeAbbreviated(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		wantError bool
+	}{
+		{
+			name:  "one octet",
+			input: "10/8",
+			want:  "10.0.0.0/8",
+		},
+		{
+			name:  "two octets",
+			input: "192.168/16",
+			want:  "192.168.0.0/16",
+		},
+		{
+			name:  "two octets zero-filled beyond the prefix",
+			input: "10.1/16",
+			want:  "10.1.0.0/16",
+		},
+		{
+			name:  "three octets",
+			input: "172.16.5/24",
+			want:  "172.16.5.0/24",
+		},
+		{
+			name:  "full address unchanged",
+			input: "192.168.0.1/24",
+			want:  "192.168.0.1/24",
+		},
+		{
+			name:      "missing prefix",
+			input:     "10",
+			wantError: true,
+		},
+		{
+			name:      "octet out of range",
+			input:     "300/8",
+			wantError: true,
+		},
+		{
+			name:      "non-numeric octet",
+			input:     "10.x/16",
+			wantError: true,
+		},
+		{
+			name:      "too many octets",
+			input:     "10.0.0.0.0/8",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ipv4.NormalizeAbbreviated(tt.input)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("NormalizeAbbreviated() expected error but got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NormalizeAbbreviated() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("NormalizeAbbreviated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCIDR_Abbreviated(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168/16")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if got := network.String(); got != "192.168.0.0/16" {
+		t.Errorf("String() = %v, want 192.168.0.0/16", got)
+	}
+}
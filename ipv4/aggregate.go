@@ -0,0 +1,134 @@
+package ipv4
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// span is an inclusive address range, held in 64 bits so last+1 can't overflow when merging
+type span struct {
+	first, last uint64
+}
+
+// Aggregate returns the minimal list of calculated networks covering exactly the same addresses as
+// nets. Overlapping, nested and adjacent networks are merged. It sorts the input by base address
+// and prefix length and merges in a single pass, so it runs in O(n log n) and copes with route
+// tables of hundreds of thousands of entries.
+func Aggregate(nets []*Network) ([]*Network, error) {
+	spans := make([]span, 0, len(nets))
+
+	for _, n := range nets {
+		first, last := n.bounds()
+		spans = append(spans, span{uint64(first), uint64(last)})
+	}
+
+	var networks []*Network
+
+	for _, s := range mergeSpans(spans) {
+		covering, err := RangeToCIDRs(fromUint32(uint32(s.first)), fromUint32(uint32(s.last)))
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, covering...)
+	}
+
+	return networks, nil
+}
+
+// AggregateToPrefix summarises nets at a coarser granularity: each network is widened to the target
+// prefix length, e.g. 10.0.1.0/24 to 10.0.0.0/16 at /16, and the unique results are returned as
+// calculated networks sorted by address. Networks already shorter than target are kept as they are,
+// and any result inside one of them is dropped. Unlike Aggregate, the result may cover addresses
+// nets didn't.
+func AggregateToPrefix(nets []*Network, target int) ([]*Network, error) {
+	if target < 0 || target > 32 {
+		return nil, fmt.Errorf("%w: /%d is outside /0 to /32", ErrInvalidPrefix, target)
+	}
+
+	widened := make([]*Network, 0, len(nets))
+
+	for _, n := range nets {
+		prefix := min(n.PrefixLength, target)
+		first, _ := (&Network{Address: n.Address, PrefixLength: prefix}).bounds()
+
+		network, err := newNetwork(first, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("newNetwork: %w", err)
+		}
+
+		widened = append(widened, network)
+	}
+
+	// Sorting puts each network before the ones it contains, so a duplicate or nested result
+	// always starts within the last one kept
+	slices.SortFunc(widened, Compare)
+
+	var networks []*Network
+
+	for _, network := range widened {
+		if len(networks) > 0 {
+			_, keptLast := networks[len(networks)-1].bounds()
+			if first, _ := network.bounds(); first <= keptLast {
+				continue
+			}
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// mergeSpans sorts the spans and merges overlapping, nested and adjacent ones, returning sorted,
+// disjoint spans
+func mergeSpans(spans []span) []span {
+	// Sorting by base then by prefix length (widest first) puts covering networks before the
+	// networks they contain
+	slices.SortFunc(spans, func(a, b span) int {
+		return cmp.Or(cmp.Compare(a.first, b.first), cmp.Compare(b.last, a.last))
+	})
+
+	// merged works as a stack whose top is the run currently being extended
+	var merged []span
+
+	for _, s := range spans {
+		if top := len(merged) - 1; top >= 0 && s.first <= merged[top].last+1 {
+			merged[top].last = max(merged[top].last, s.last)
+			continue
+		}
+
+		merged = append(merged, s)
+	}
+
+	return merged
+}
+
+// CoverageGaps returns the minimal list of calculated networks covering the address space between
+// the lowest and highest of nets that none of them covers, i.e. the holes in an allocation plan
+// meant to tile a range. Overlapping and unsorted input is fine. It returns no networks if the
+// plan is contiguous.
+func CoverageGaps(nets []*Network) ([]*Network, error) {
+	spans := make([]span, 0, len(nets))
+
+	for _, n := range nets {
+		first, last := n.bounds()
+		spans = append(spans, span{uint64(first), uint64(last)})
+	}
+
+	merged := mergeSpans(spans)
+
+	var gaps []*Network
+
+	for i := 1; i < len(merged); i++ {
+		covering, err := RangeToCIDRs(fromUint32(uint32(merged[i-1].last+1)), fromUint32(uint32(merged[i].first-1)))
+		if err != nil {
+			return nil, fmt.Errorf("RangeToCIDRs: %w", err)
+		}
+
+		gaps = append(gaps, covering...)
+	}
+
+	return gaps, nil
+}
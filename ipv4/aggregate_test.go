@@ -0,0 +1,279 @@
+package ipv4_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func parseNetworks(t testing.TB, cidrs []string) []*ipv4.Network {
+	t.Helper()
+
+	networks := make([]*ipv4.Network, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		network, err := ipv4.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("ParseCIDR(%q) error = %v", cidr, err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+func networkStrings(networks []*ipv4.Network) string {
+	s := make([]string, 0, len(networks))
+	for _, network := range networks {
+		s = append(s, network.String())
+	}
+
+	return strings.Join(s, " ")
+}
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  string
+	}{
+		{
+			name:  "adjacent halves",
+			input: []string{"10.0.0.128/25", "10.0.0.0/25"},
+			want:  "10.0.0.0/24",
+		},
+		{
+			name:  "nested",
+			input: []string{"10.0.0.64/26", "10.0.0.0/24", "10.0.0.7/32"},
+			want:  "10.0.0.0/24",
+		},
+		{
+			name:  "adjacent but unaligned",
+			input: []string{"10.0.1.0/24", "10.0.2.0/24"},
+			want:  "10.0.1.0/24 10.0.2.0/24",
+		},
+		{
+			name:  "disjoint",
+			input: []string{"192.168.0.0/24", "10.0.0.0/8"},
+			want:  "10.0.0.0/8 192.168.0.0/24",
+		},
+		{
+			name:  "host bits ignored",
+			input: []string{"10.0.0.1/31", "10.0.0.3/31"},
+			want:  "10.0.0.0/30",
+		},
+		{
+			name:  "whole space",
+			input: []string{"128.0.0.0/1", "0.0.0.0/1", "255.255.255.255/32"},
+			want:  "0.0.0.0/0",
+		},
+		{
+			name:  "empty",
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ipv4.Aggregate(parseNetworks(t, tt.input))
+			if err != nil {
+				t.Fatalf("Aggregate() error = %v", err)
+			}
+
+			if s := networkStrings(got); s != tt.want {
+				t.Errorf("Aggregate() = %q, want %q", s, tt.want)
+			}
+		})
+	}
+}
+
+// bruteForceAggregate marks every address of each network inside 10.0.0.0/24 and converts each
+// run of marked addresses to CIDRs
+func bruteForceAggregate(t *testing.T, cidrs []string) string {
+	t.Helper()
+
+	var covered [256]bool
+
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("net.ParseCIDR(%q) error = %v", cidr, err)
+		}
+
+		for i := range covered {
+			if ipNet.Contains(net.IPv4(10, 0, 0, byte(i))) {
+				covered[i] = true
+			}
+		}
+	}
+
+	var result []*ipv4.Network
+
+	for i := 0; i < len(covered); i++ {
+		if !covered[i] {
+			continue
+		}
+
+		start := i
+		for i+1 < len(covered) && covered[i+1] {
+			i++
+		}
+
+		networks, err := ipv4.RangeToCIDRs(net.IPv4(10, 0, 0, byte(start)), net.IPv4(10, 0, 0, byte(i)))
+		if err != nil {
+			t.Fatalf("RangeToCIDRs() error = %v", err)
+		}
+
+		result = append(result, networks...)
+	}
+
+	return networkStrings(result)
+}
+
+func TestAggregate_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for round := range 500 {
+		cidrs := make([]string, 1+rng.IntN(12))
+		for i := range cidrs {
+			cidrs[i] = fmt.Sprintf("10.0.0.%d/%d", rng.IntN(256), 24+rng.IntN(9))
+		}
+
+		got, err := ipv4.Aggregate(parseNetworks(t, cidrs))
+		if err != nil {
+			t.Fatalf("Aggregate() error = %v", err)
+		}
+
+		if s, want := networkStrings(got), bruteForceAggregate(t, cidrs); s != want {
+			t.Fatalf("round %d: Aggregate(%v) = %q, want %q", round, cidrs, s, want)
+		}
+	}
+}
+
+func BenchmarkAggregate(b *testing.B) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	cidrs := make([]string, 100_000)
+	for i := range cidrs {
+		cidrs[i] = fmt.Sprintf("10.%d.%d.0/%d", rng.IntN(256), rng.IntN(256), 20+rng.IntN(5))
+	}
+
+	networks := parseNetworks(b, cidrs)
+
+	for b.Loop() {
+		if _, err := ipv4.Aggregate(networks); err != nil {
+			b.Fatalf("Aggregate() error = %v", err)
+		}
+	}
+}
+
+func TestCoverageGaps(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  string
+	}{
+		{
+			name:  "missing upper half",
+			input: []string{"10.0.0.0/25", "10.0.1.0/24"},
+			want:  "10.0.0.128/25",
+		},
+		{
+			name:  "unaligned gap",
+			input: []string{"10.0.1.0/24", "10.0.0.0/30", "10.0.0.64/26"},
+			want:  "10.0.0.4/30 10.0.0.8/29 10.0.0.16/28 10.0.0.32/27 10.0.0.128/25",
+		},
+		{
+			name:  "contiguous",
+			input: []string{"10.0.0.128/25", "10.0.0.0/25", "10.0.1.0/24"},
+			want:  "",
+		},
+		{
+			name:  "overlapping",
+			input: []string{"10.0.0.0/24", "10.0.0.64/26", "10.0.2.0/24"},
+			want:  "10.0.1.0/24",
+		},
+		{
+			name:  "single network",
+			input: []string{"10.0.0.0/24"},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ipv4.CoverageGaps(parseNetworks(t, tt.input))
+			if err != nil {
+				t.Fatalf("CoverageGaps() error = %v", err)
+			}
+
+			if s := networkStrings(got); s != tt.want {
+				t.Errorf("CoverageGaps() = %q, want %q", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateToPrefix(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []string
+		target int
+		want   string
+	}{
+		{
+			name:   "siblings share a /16",
+			input:  []string{"10.0.1.0/24", "10.0.2.0/24"},
+			target: 16,
+			want:   "10.0.0.0/16",
+		},
+		{
+			name:   "distinct /16s sorted",
+			input:  []string{"10.1.5.0/24", "10.0.1.0/24", "10.1.9.128/25"},
+			target: 16,
+			want:   "10.0.0.0/16 10.1.0.0/16",
+		},
+		{
+			name:   "shorter entry kept and absorbs others",
+			input:  []string{"10.2.0.0/24", "10.0.0.0/8", "192.168.1.0/24"},
+			target: 16,
+			want:   "10.0.0.0/8 192.168.0.0/16",
+		},
+		{
+			name:   "host bits cleared",
+			input:  []string{"10.0.1.77/32"},
+			target: 24,
+			want:   "10.0.1.0/24",
+		},
+		{
+			name:   "empty",
+			input:  nil,
+			target: 16,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ipv4.AggregateToPrefix(parseNetworks(t, tt.input), tt.target)
+			if err != nil {
+				t.Fatalf("AggregateToPrefix() error = %v", err)
+			}
+
+			if s := networkStrings(got); s != tt.want {
+				t.Errorf("AggregateToPrefix() = %q, want %q", s, tt.want)
+			}
+		})
+	}
+
+	if _, err := ipv4.AggregateToPrefix(nil, 33); !errors.Is(err, ipv4.ErrInvalidPrefix) {
+		t.Errorf("AggregateToPrefix(/33) error = %v, want ErrInvalidPrefix", err)
+	}
+}
@@ -0,0 +1,172 @@
+package ipv4
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AllocationMap renders the network as a bar width characters wide, each character standing for
+// an equal share of its addresses: # where at least half the share is allocated and a space where
+// it's mostly free. A second line gives the allocated and free percentages. The free space is the
+// networks FreeSubnets returns and the percentages are those of Utilization, so overlapping
+// allocations are counted once and parts outside n are ignored. It returns an empty string if
+// width isn't positive.
+func (n *Network) AllocationMap(allocated []*Network, width int) (string, error) {
+	if width <= 0 {
+		return "", nil
+	}
+
+	free, err := n.FreeSubnets(allocated)
+	if err != nil {
+		return "", fmt.Errorf("FreeSubnets: %w", err)
+	}
+
+	first, last := n.bounds()
+	parent := span{uint64(first), uint64(last)}
+	total := parent.last - parent.first + 1
+
+	// FreeSubnets returns sorted, disjoint networks, as overlap expects
+	spans := make([]span, 0, len(free))
+
+	for _, f := range free {
+		fFirst, fLast := f.bounds()
+		spans = append(spans, span{uint64(fFirst), uint64(fLast)})
+	}
+
+	var bar strings.Builder
+
+	for i := range uint64(width) {
+		start, end := parent.first+i*total/uint64(width), parent.first+(i+1)*total/uint64(width)
+		if end == start {
+			// More cells than addresses; show the address this cell falls on
+			end++
+		}
+
+		cell := span{start, end - 1}
+		size := cell.last - cell.first + 1
+
+		if 2*(size-overlap(spans, cell)) >= size {
+			bar.WriteByte('#')
+		} else {
+			bar.WriteByte(' ')
+		}
+	}
+
+	percent := n.utilization(free)
+
+	return fmt.Sprintf("%s\n%.1f%% allocated, %.1f%% free", bar.String(), percent, 100-percent), nil
+}
+
+// Utilization returns the percentage of n's addresses covered by allocated, from 0 to 100, taking
+// the free space from FreeSubnets. Overlapping allocations are counted once and parts outside n
+// are ignored.
+func (n *Network) Utilization(allocated []*Network) (float64, error) {
+	free, err := n.FreeSubnets(allocated)
+	if err != nil {
+		return 0, fmt.Errorf("FreeSubnets: %w", err)
+	}
+
+	return n.utilization(free), nil
+}
+
+// utilization returns the percentage of n's addresses outside the free networks
+func (n *Network) utilization(free []*Network) float64 {
+	total := n.TotalAddresses()
+
+	var unallocated uint64
+	for _, f := range free {
+		unallocated += f.TotalAddresses()
+	}
+
+	return 100 * float64(total-unallocated) / float64(total)
+}
+
+// FreeSubnets returns the minimal list of calculated networks covering the parts of n that none of
+// allocated covers, in ascending order. Overlapping allocations are fine and parts outside n are
+// ignored.
+func (n *Network) FreeSubnets(allocated []*Network) ([]*Network, error) {
+	var free []*Network
+
+	for _, s := range n.freeSpans(allocated) {
+		covering, err := RangeToCIDRs(fromUint32(uint32(s.first)), fromUint32(uint32(s.last)))
+		if err != nil {
+			return nil, fmt.Errorf("RangeToCIDRs: %w", err)
+		}
+
+		free = append(free, covering...)
+	}
+
+	return free, nil
+}
+
+// RemainingSubnets returns how many aligned subnets of the subnetPrefix length could still be
+// handed out from n given the allocated networks, e.g. 3 /26s in a /24 with one /26 taken. It
+// returns ErrInvalidPrefix if subnetPrefix is shorter than n's prefix or longer than /32.
+func (n *Network) RemainingSubnets(subnetPrefix int, allocated []*Network) (int, error) {
+	if subnetPrefix < n.PrefixLength || subnetPrefix > 32 {
+		return 0, fmt.Errorf("%w: /%d isn't within /%d to /32", ErrInvalidPrefix, subnetPrefix, n.PrefixLength)
+	}
+
+	free, err := n.FreeSubnets(allocated)
+	if err != nil {
+		return 0, fmt.Errorf("FreeSubnets: %w", err)
+	}
+
+	count := 0
+
+	// The free blocks are aligned, so each one at least as large as a subnet splits into whole
+	// subnets and smaller ones hold none
+	for _, block := range free {
+		if block.PrefixLength <= subnetPrefix {
+			count += 1 << (subnetPrefix - block.PrefixLength)
+		}
+	}
+
+	return count, nil
+}
+
+// freeSpans returns the sorted, disjoint spans of n that none of allocated covers
+func (n *Network) freeSpans(allocated []*Network) []span {
+	first, last := n.bounds()
+	parent := span{uint64(first), uint64(last)}
+
+	spans := make([]span, 0, len(allocated))
+
+	for _, a := range allocated {
+		aFirst, aLast := a.bounds()
+		if s := (span{max(uint64(aFirst), parent.first), min(uint64(aLast), parent.last)}); s.first <= s.last {
+			spans = append(spans, s)
+		}
+	}
+
+	var free []span
+
+	next := parent.first
+
+	for _, s := range mergeSpans(spans) {
+		if s.first > next {
+			free = append(free, span{next, s.first - 1})
+		}
+
+		next = s.last + 1
+	}
+
+	if next <= parent.last {
+		free = append(free, span{next, parent.last})
+	}
+
+	return free
+}
+
+// overlap returns the number of addresses of the sorted, disjoint spans that fall within s
+func overlap(spans []span, s span) uint64 {
+	var count uint64
+
+	for _, a := range spans {
+		if first, last := max(a.first, s.first), min(a.last, s.last); first <= last {
+			count += last - first + 1
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,185 @@
+package ipv4_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_AllocationMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		parent    string
+		allocated []string
+		width     int
+		wantBar   string
+		wantLabel string
+	}{
+		{
+			name:      "half allocated",
+			parent:    "10.0.0.0/24",
+			allocated: []string{"10.0.0.0/25"},
+			width:     40,
+			wantBar:   strings.Repeat("#", 20) + strings.Repeat(" ", 20),
+			wantLabel: "50.0% allocated, 50.0% free",
+		},
+		{
+			name:      "overlapping and outside allocations",
+			parent:    "10.0.0.0/24",
+			allocated: []string{"10.0.0.192/26", "10.0.0.224/27", "10.0.1.0/24"},
+			width:     8,
+			wantBar:   "      ##",
+			wantLabel: "25.0% allocated, 75.0% free",
+		},
+		{
+			name:      "nothing allocated",
+			parent:    "10.0.0.0/24",
+			width:     4,
+			wantBar:   "    ",
+			wantLabel: "0.0% allocated, 100.0% free",
+		},
+		{
+			name:      "more cells than addresses",
+			parent:    "0.0.0.0/30",
+			allocated: []string{"0.0.0.0/31"},
+			width:     8,
+			wantBar:   "####    ",
+			wantLabel: "50.0% allocated, 50.0% free",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := ipv4.ParseCIDR(tt.parent)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			var allocated []*ipv4.Network
+
+			for _, cidr := range tt.allocated {
+				network, err := ipv4.ParseCIDR(cidr)
+				if err != nil {
+					t.Fatalf("ParseCIDR() error = %v", err)
+				}
+
+				allocated = append(allocated, network)
+			}
+
+			output, err := parent.AllocationMap(allocated, tt.width)
+			if err != nil {
+				t.Fatalf("AllocationMap() error = %v", err)
+			}
+
+			bar, label, _ := strings.Cut(output, "\n")
+			if len(bar) != tt.width {
+				t.Errorf("bar length = %d, want %d", len(bar), tt.width)
+			}
+
+			if bar != tt.wantBar || label != tt.wantLabel {
+				t.Errorf("AllocationMap() = %q, %q, want %q, %q", bar, label, tt.wantBar, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestNetwork_Utilization(t *testing.T) {
+	tests := []struct {
+		name      string
+		allocated []string
+		want      float64
+	}{
+		{"half allocated", []string{"10.0.0.0/25"}, 50},
+		{"overlapping and outside", []string{"10.0.0.192/26", "10.0.0.224/27", "10.0.1.0/24"}, 25},
+		{"nothing allocated", nil, 0},
+		{"fully allocated", []string{"10.0.0.0/16"}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := ipv4.ParseCIDR("10.0.0.0/24")
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			got, err := parent.Utilization(parseNetworks(t, tt.allocated))
+			if err != nil {
+				t.Fatalf("Utilization() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Utilization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_RemainingSubnets(t *testing.T) {
+	tests := []struct {
+		name         string
+		parent       string
+		allocated    []string
+		subnetPrefix int
+		want         int
+		wantFree     []string
+		wantErr      error
+	}{
+		{"one /26 taken", "10.0.0.0/24", []string{"10.0.0.0/26"}, 26, 3, []string{"10.0.0.64/26", "10.0.0.128/25"}, nil},
+		{"nothing taken", "10.0.0.0/24", nil, 26, 4, []string{"10.0.0.0/24"}, nil},
+		{"unaligned host splits a /26", "10.0.0.0/24", []string{"10.0.0.70/32"}, 26, 3, []string{"10.0.0.0/26", "10.0.0.64/30", "10.0.0.68/31", "10.0.0.71/32", "10.0.0.72/29", "10.0.0.80/28", "10.0.0.96/27", "10.0.0.128/25"}, nil},
+		{"overlapping and outside", "10.0.0.0/24", []string{"10.0.0.0/25", "10.0.0.64/26", "192.168.0.0/16"}, 27, 4, []string{"10.0.0.128/25"}, nil},
+		{"fully taken", "10.0.0.0/24", []string{"10.0.0.0/16"}, 26, 0, nil, nil},
+		{"shorter than the parent", "10.0.0.0/24", nil, 23, 0, nil, ipv4.ErrInvalidPrefix},
+		{"longer than /32", "10.0.0.0/24", nil, 33, 0, nil, ipv4.ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := ipv4.ParseCIDR(tt.parent)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			var allocated []*ipv4.Network
+
+			for _, cidr := range tt.allocated {
+				network, err := ipv4.ParseCIDR(cidr)
+				if err != nil {
+					t.Fatalf("ParseCIDR() error = %v", err)
+				}
+
+				allocated = append(allocated, network)
+			}
+
+			got, err := parent.RemainingSubnets(tt.subnetPrefix, allocated)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RemainingSubnets() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("RemainingSubnets() = %d, want %d", got, tt.want)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			free, err := parent.FreeSubnets(allocated)
+			if err != nil {
+				t.Fatalf("FreeSubnets() error = %v", err)
+			}
+
+			var gotFree []string
+			for _, network := range free {
+				gotFree = append(gotFree, network.String())
+			}
+
+			if !slices.Equal(gotFree, tt.wantFree) {
+				t.Errorf("FreeSubnets() = %v, want %v", gotFree, tt.wantFree)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package ipv4
+
+import "net"
+
+// ClassfulNetwork returns the natural classful network of the address and its broadcast address,
+// ignoring the CIDR prefix length: a /8 for Class A, /16 for Class B and /24 for Class C, as a
+// classful router would assume. It returns nil values for Class D and E addresses, which have no
+// natural network.
+func (n *Network) ClassfulNetwork() (network net.IP, broadcast net.IP) {
+	var prefix int
+
+	switch classifyAddress(n.Address.To4()) {
+	case "A":
+		prefix = 8
+	case "B":
+		prefix = 16
+	case "C":
+		prefix = 24
+	default:
+		return nil, nil
+	}
+
+	natural := &Network{Address: n.Address, PrefixLength: prefix}
+	first, last := natural.bounds()
+
+	return fromUint32(first), fromUint32(last)
+}
@@ -0,0 +1,35 @@
+package ipv4_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_ClassfulNetwork(t *testing.T) {
+	tests := []struct {
+		cidr          string
+		wantNetwork   string
+		wantBroadcast string
+	}{
+		{"10.1.2.3/24", "10.0.0.0", "10.255.255.255"},
+		{"172.16.5.4/12", "172.16.0.0", "172.16.255.255"},
+		{"192.168.1.77/30", "192.168.1.0", "192.168.1.255"},
+		{"224.0.0.1/32", "<nil>", "<nil>"},
+		{"240.0.0.1/8", "<nil>", "<nil>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			gotNetwork, gotBroadcast := network.ClassfulNetwork()
+			if gotNetwork.String() != tt.wantNetwork || gotBroadcast.String() != tt.wantBroadcast {
+				t.Errorf("ClassfulNetwork() = %v, %v, want %s, %s", gotNetwork, gotBroadcast, tt.wantNetwork, tt.wantBroadcast)
+			}
+		})
+	}
+}
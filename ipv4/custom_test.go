@@ -0,0 +1,44 @@
+package ipv4_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_CalculateCustomRanges(t *testing.T) {
+	_, dmz, err := net.ParseCIDR("100.127.0.0/16")
+	if err != nil {
+		t.Fatalf("net.ParseCIDR() error = %v", err)
+	}
+
+	ranges := []ipv4.CustomRange{{Network: dmz, Label: "Corp DMZ"}}
+
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{"100.127.4.1/24", "Corp DMZ"},
+		{"100.64.0.1/24", "Shared Address Space"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.CustomRanges = ranges
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if network.Type != tt.want {
+				t.Errorf("Type = %q, want %q", network.Type, tt.want)
+			}
+		})
+	}
+}
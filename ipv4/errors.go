@@ -4,4 +4,8 @@ import "errors"
 
 var (
 	ErrInvalidAddress = errors.New("invalid address")
+	ErrInvalidPrefix  = errors.New("invalid prefix length")
+	ErrHostOutOfRange = errors.New("host out of range")
+	ErrInvalidRange   = errors.New("invalid range")
+	ErrNotContained   = errors.New("network not contained")
 )
@@ -0,0 +1,42 @@
+package ipv4
+
+import "fmt"
+
+// Exclude returns the minimal list of calculated networks covering n with remove taken out. It
+// returns ErrNotContained if remove isn't entirely inside n.
+func (n *Network) Exclude(remove *Network) ([]*Network, error) {
+	first, last := n.bounds()
+	removeFirst, removeLast := remove.bounds()
+
+	if removeFirst < first || removeLast > last {
+		return nil, fmt.Errorf("%w: %s is not inside %s", ErrNotContained, remove, n)
+	}
+
+	var remaining []*Network
+
+	if removeFirst > first {
+		below, err := RangeToCIDRs(fromUint32(first), fromUint32(removeFirst-1))
+		if err != nil {
+			return nil, err
+		}
+
+		remaining = append(remaining, below...)
+	}
+
+	if removeLast < last {
+		above, err := RangeToCIDRs(fromUint32(removeLast+1), fromUint32(last))
+		if err != nil {
+			return nil, err
+		}
+
+		remaining = append(remaining, above...)
+	}
+
+	return remaining, nil
+}
+
+// ComplementWithin returns the minimal list of calculated networks covering parent with n taken
+// out, i.e. the sibling space around n. It returns ErrNotContained if n isn't inside parent.
+func (n *Network) ComplementWithin(parent *Network) ([]*Network, error) {
+	return parent.Exclude(n)
+}
@@ -0,0 +1,158 @@
+package ipv4_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_Exclude(t *testing.T) {
+	tests := []struct {
+		name      string
+		parent    string
+		remove    string
+		want      []string
+		wantError error
+	}{
+		{
+			name:   "middle block",
+			parent: "10.0.0.0/24",
+			remove: "10.0.0.64/26",
+			want:   []string{"10.0.0.0/26", "10.0.0.128/25"},
+		},
+		{
+			name:   "first half",
+			parent: "10.0.0.0/24",
+			remove: "10.0.0.0/25",
+			want:   []string{"10.0.0.128/25"},
+		},
+		{
+			name:   "single address",
+			parent: "10.0.0.0/30",
+			remove: "10.0.0.2/32",
+			want:   []string{"10.0.0.0/31", "10.0.0.3/32"},
+		},
+		{
+			name:   "whole network",
+			parent: "10.0.0.0/24",
+			remove: "10.0.0.0/24",
+			want:   nil,
+		},
+		{
+			name:      "not contained",
+			parent:    "10.0.0.0/24",
+			remove:    "10.0.1.0/26",
+			wantError: ipv4.ErrNotContained,
+		},
+		{
+			name:      "larger than parent",
+			parent:    "10.0.0.0/24",
+			remove:    "10.0.0.0/23",
+			wantError: ipv4.ErrNotContained,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := ipv4.ParseCIDR(tt.parent)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			remove, err := ipv4.ParseCIDR(tt.remove)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			networks, err := parent.Exclude(remove)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("Exclude() error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Exclude() error = %v", err)
+			}
+
+			if len(networks) != len(tt.want) {
+				t.Fatalf("Exclude() returned %d networks, want %v", len(networks), tt.want)
+			}
+
+			for i, network := range networks {
+				if network.String() != tt.want[i] {
+					t.Errorf("Exclude()[%d] = %v, want %v", i, network, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNetwork_ComplementWithin(t *testing.T) {
+	tests := []struct {
+		name      string
+		network   string
+		parent    string
+		want      []string
+		wantError error
+	}{
+		{
+			name:    "second /24 of a /22",
+			network: "10.0.1.0/24",
+			parent:  "10.0.0.0/22",
+			want:    []string{"10.0.0.0/24", "10.0.2.0/23"},
+		},
+		{
+			name:    "last /24 of a /22",
+			network: "10.0.3.0/24",
+			parent:  "10.0.0.0/22",
+			want:    []string{"10.0.0.0/23", "10.0.2.0/24"},
+		},
+		{
+			name:      "outside parent",
+			network:   "10.0.4.0/24",
+			parent:    "10.0.0.0/22",
+			wantError: ipv4.ErrNotContained,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.network)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			parent, err := ipv4.ParseCIDR(tt.parent)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			complement, err := network.ComplementWithin(parent)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("ComplementWithin() error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ComplementWithin() error = %v", err)
+			}
+
+			if len(complement) != len(tt.want) {
+				t.Fatalf("ComplementWithin() returned %d networks, want %v", len(complement), tt.want)
+			}
+
+			for i, network := range complement {
+				if network.String() != tt.want[i] {
+					t.Errorf("ComplementWithin()[%d] = %v, want %v", i, network, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package ipv4
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Fingerprint returns a stable short hash of the canonical network, the first 8 hex digits of the
+// SHA-256 of its network address and prefix length, e.g. for detecting config drift. Addresses
+// with host bits set share the fingerprint of their network.
+func (n *Network) Fingerprint() string {
+	first, _ := n.bounds()
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s/%d", fromUint32(first), n.PrefixLength))
+
+	return hex.EncodeToString(sum[:4])
+}
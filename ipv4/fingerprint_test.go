@@ -0,0 +1,48 @@
+package ipv4_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_Fingerprint(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		// First 8 hex digits of sha256("10.0.0.0/24")
+		{"10.0.0.0/24", "a34d80f7"},
+		{"10.0.0.5/24", "a34d80f7"},
+		{"10.0.0.255/24", "a34d80f7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.Fingerprint(); got != tt.want {
+				t.Errorf("Fingerprint() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_FingerprintDiffersByPrefix(t *testing.T) {
+	a, err := ipv4.ParseCIDR("10.0.0.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	b, err := ipv4.ParseCIDR("10.0.0.0/25")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Errorf("Fingerprint() of %s and %s should differ", a, b)
+	}
+}
@@ -0,0 +1,176 @@
+package ipv4
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"net"
+)
+
+// WithHost returns the address formed by placing host into the host portion of the network. It
+// returns ErrInvalidPrefix if the prefix length is outside /0 to /32, and ErrHostOutOfRange if host
+// doesn't fit in the available host bits.
+func (n *Network) WithHost(host uint32) (net.IP, error) {
+	if n.PrefixLength < 0 || n.PrefixLength > 32 {
+		return nil, fmt.Errorf("%w: /%d is outside /0 to /32", ErrInvalidPrefix, n.PrefixLength)
+	}
+
+	hostBits := 32 - n.PrefixLength
+	if hostBits < 32 && host >= 1<<hostBits {
+		return nil, fmt.Errorf("%w: %d needs more than %d host bits", ErrHostOutOfRange, host, hostBits)
+	}
+
+	network := toUint32(n.Address) & toUint32(net.IP(net.CIDRMask(n.PrefixLength, 32)))
+
+	return fromUint32(network | host), nil
+}
+
+// FirstUsable returns the first address that can be assigned to a host, conventionally used as the
+// gateway. Point-to-point /31 networks (RFC 3021) and /32 host routes have no reserved addresses.
+func (n *Network) FirstUsable() net.IP {
+	if n.PrefixLength >= 31 {
+		return n.Network
+	}
+
+	return n.HostMin
+}
+
+// LastUsable returns the last address that can be assigned to a host, i.e. the one just below the
+// broadcast address. Point-to-point /31 networks (RFC 3021) and /32 host routes have no reserved
+// addresses.
+func (n *Network) LastUsable() net.IP {
+	if n.PrefixLength >= 31 {
+		return n.Broadcast
+	}
+
+	return n.HostMax
+}
+
+// Hosts returns an iterator over the usable host addresses in ascending order
+func (n *Network) Hosts() iter.Seq[net.IP] {
+	return func(yield func(net.IP) bool) {
+		first, last := n.usableBounds()
+
+		for addr := first; ; addr++ {
+			if !yield(fromUint32(addr)) || addr == last {
+				return
+			}
+		}
+	}
+}
+
+// HostsDescending returns an iterator over the usable host addresses from the highest down, handy
+// when assigning gateways from the top of the range
+func (n *Network) HostsDescending() iter.Seq[net.IP] {
+	return func(yield func(net.IP) bool) {
+		first, last := n.usableBounds()
+
+		for addr := last; ; addr-- {
+			if !yield(fromUint32(addr)) || addr == first {
+				return
+			}
+		}
+	}
+}
+
+// usableBounds returns the first and last usable host addresses, with the same /31 and /32
+// handling as FirstUsable and LastUsable
+func (n *Network) usableBounds() (uint32, uint32) {
+	first, last := n.bounds()
+	if n.PrefixLength >= 31 {
+		return first, last
+	}
+
+	return first + 1, last - 1
+}
+
+// PointToPoint returns the two endpoint addresses of a point-to-point link: the two usable hosts of
+// a /30, or both addresses of a /31 (RFC 3021). It returns ErrInvalidPrefix for other prefixes.
+func (n *Network) PointToPoint() (a, b net.IP, err error) {
+	first, last := n.usableBounds()
+
+	switch n.PrefixLength {
+	case 30, 31:
+		return fromUint32(first), fromUint32(last), nil
+	default:
+		return nil, nil, fmt.Errorf("%w: point-to-point links need /30 or /31, got /%d", ErrInvalidPrefix, n.PrefixLength)
+	}
+}
+
+// Shortest and longest prefix lengths SpecialHostRoles considers; /31 and /32 have no network or
+// broadcast address to reserve
+const (
+	minRolePrefixLength = 8
+	maxRolePrefixLength = 30
+)
+
+// SpecialHostRoles returns, for each common prefix length from /8 to /30 where the address would be
+// the network (all-zeros host) or broadcast (all-ones host) address, the label "network" or
+// "broadcast", e.g. 10.0.0.0 is the network address of the /8, /16 and /24 around it. Such
+// addresses are usually a mistake to assign to a host. It returns nil for non-IPv4 addresses.
+func SpecialHostRoles(ip net.IP) map[int]string {
+	if ip.To4() == nil {
+		return nil
+	}
+
+	addr := toUint32(ip)
+	roles := make(map[int]string)
+
+	for prefix := minRolePrefixLength; prefix <= maxRolePrefixLength; prefix++ {
+		hostMask := uint32(1)<<(32-prefix) - 1
+
+		switch addr & hostMask {
+		case 0:
+			roles[prefix] = "network"
+		case hostMask:
+			roles[prefix] = "broadcast"
+		}
+	}
+
+	return roles
+}
+
+// TotalAddresses returns the number of addresses in the network including the network and
+// broadcast addresses, as a uint64 since a /0 holds 2^32
+func (n *Network) TotalAddresses() uint64 {
+	first, last := n.bounds()
+
+	return uint64(last-first) + 1
+}
+
+// UsableHosts returns the number of addresses that can be assigned to hosts, with the same /31 and
+// /32 handling as FirstUsable and LastUsable: both addresses of a /31 (RFC 3021) and the single
+// address of a /32 count, where HostCount has 0
+func (n *Network) UsableHosts() uint64 {
+	first, last := n.usableBounds()
+
+	return uint64(last-first) + 1
+}
+
+// BitCounts returns how the 32 address bits split into network bits (the prefix length) and host
+// bits, e.g. 26 and 6 for a /26
+func (n *Network) BitCounts() (networkBits, hostBits int) {
+	return n.PrefixLength, 32 - n.PrefixLength
+}
+
+// PrefixForHosts returns the longest prefix length, i.e. the smallest network, with at least hosts
+// usable addresses, counting /31 and /32 as RFC 3021 point-to-point and host routes do: /23 for
+// 500 hosts and /31 for 2. It returns ErrHostOutOfRange if hosts isn't between 1 and the 2^32-2
+// a /0 can hold.
+func PrefixForHosts(hosts int) (int, error) {
+	if hosts < 1 || uint64(hosts) > 1<<32-2 {
+		return 0, fmt.Errorf("%w: %d hosts don't fit in any IPv4 network", ErrHostOutOfRange, hosts)
+	}
+
+	switch hosts {
+	case 1:
+		return 32, nil
+	case 2:
+		return 31, nil
+	}
+
+	// Reserve the network and broadcast addresses, then round up to a power of two
+	hostBits := bits.Len64(uint64(hosts) + 1)
+
+	return 32 - hostBits, nil
+}
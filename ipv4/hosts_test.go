@@ -0,0 +1,435 @@
+package ipv4_test
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_WithHost(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		host      uint32
+		want      string
+		wantError bool
+	}{
+		{
+			name: "first host of /24",
+			cidr: "10.0.0.0/24",
+			host: 1,
+			want: "10.0.0.1",
+		},
+		{
+			name: "host bits in input are replaced",
+			cidr: "10.0.0.77/24",
+			host: 200,
+			want: "10.0.0.200",
+		},
+		{
+			name: "host spanning octets",
+			cidr: "172.16.0.0/12",
+			host: 0x10203,
+			want: "172.17.2.3",
+		},
+		{
+			name: "any host fits in /0",
+			cidr: "0.0.0.0/0",
+			host: 0xffffffff,
+			want: "255.255.255.255",
+		},
+		{
+			name:      "host exceeds /24",
+			cidr:      "10.0.0.0/24",
+			host:      256,
+			wantError: true,
+		},
+		{
+			name:      "no host bits in /32",
+			cidr:      "10.0.0.1/32",
+			host:      1,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			got, err := network.WithHost(tt.host)
+			if tt.wantError {
+				if !errors.Is(err, ipv4.ErrHostOutOfRange) {
+					t.Errorf("WithHost() error = %v, want %v", err, ipv4.ErrHostOutOfRange)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("WithHost() error = %v", err)
+			}
+
+			if got.String() != tt.want {
+				t.Errorf("WithHost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_WithHostInvalidPrefix(t *testing.T) {
+	for _, prefix := range []int{-1, 33} {
+		t.Run(fmt.Sprintf("/%d", prefix), func(t *testing.T) {
+			network := &ipv4.Network{Address: net.IPv4(10, 0, 0, 0), PrefixLength: prefix}
+
+			if _, err := network.WithHost(1); !errors.Is(err, ipv4.ErrInvalidPrefix) {
+				t.Errorf("WithHost() error = %v, want %v", err, ipv4.ErrInvalidPrefix)
+			}
+		})
+	}
+}
+
+func TestNetwork_FirstAndLastUsable(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		wantFirst string
+		wantLast  string
+	}{
+		{
+			name:      "/24 matches host range",
+			cidr:      "10.0.0.0/24",
+			wantFirst: "10.0.0.1",
+			wantLast:  "10.0.0.254",
+		},
+		{
+			name:      "/30 matches host range",
+			cidr:      "192.168.1.4/30",
+			wantFirst: "192.168.1.5",
+			wantLast:  "192.168.1.6",
+		},
+		{
+			name:      "/31 uses both addresses",
+			cidr:      "192.168.1.4/31",
+			wantFirst: "192.168.1.4",
+			wantLast:  "192.168.1.5",
+		},
+		{
+			name:      "/32 is the single host",
+			cidr:      "192.168.1.9/32",
+			wantFirst: "192.168.1.9",
+			wantLast:  "192.168.1.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			err = network.Calculate()
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if got := network.FirstUsable().String(); got != tt.wantFirst {
+				t.Errorf("FirstUsable() = %v, want %v", got, tt.wantFirst)
+			}
+
+			if got := network.LastUsable().String(); got != tt.wantLast {
+				t.Errorf("LastUsable() = %v, want %v", got, tt.wantLast)
+			}
+
+			if network.HostCount > 0 {
+				if !network.FirstUsable().Equal(network.HostMin) {
+					t.Errorf("FirstUsable() = %v, want HostMin %v", network.FirstUsable(), network.HostMin)
+				}
+
+				if !network.LastUsable().Equal(network.HostMax) {
+					t.Errorf("LastUsable() = %v, want HostMax %v", network.LastUsable(), network.HostMax)
+				}
+			}
+		})
+	}
+}
+
+func TestNetwork_Hosts(t *testing.T) {
+	tests := []struct {
+		name           string
+		cidr           string
+		wantAscending  []string
+		wantDescending []string
+	}{
+		{
+			name:           "/29",
+			cidr:           "10.0.0.0/29",
+			wantAscending:  []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"},
+			wantDescending: []string{"10.0.0.6", "10.0.0.5", "10.0.0.4", "10.0.0.3", "10.0.0.2", "10.0.0.1"},
+		},
+		{
+			name:           "/31 point-to-point",
+			cidr:           "10.0.0.0/31",
+			wantAscending:  []string{"10.0.0.0", "10.0.0.1"},
+			wantDescending: []string{"10.0.0.1", "10.0.0.0"},
+		},
+		{
+			name:           "/32 host route",
+			cidr:           "255.255.255.255/32",
+			wantAscending:  []string{"255.255.255.255"},
+			wantDescending: []string{"255.255.255.255"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			var ascending, descending []string
+			for ip := range network.Hosts() {
+				ascending = append(ascending, ip.String())
+			}
+
+			for ip := range network.HostsDescending() {
+				descending = append(descending, ip.String())
+			}
+
+			if strings.Join(ascending, ",") != strings.Join(tt.wantAscending, ",") {
+				t.Errorf("Hosts() = %v, want %v", ascending, tt.wantAscending)
+			}
+
+			if strings.Join(descending, ",") != strings.Join(tt.wantDescending, ",") {
+				t.Errorf("HostsDescending() = %v, want %v", descending, tt.wantDescending)
+			}
+		})
+	}
+}
+
+func TestNetwork_HostsDescendingStopsEarly(t *testing.T) {
+	network, err := ipv4.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	for ip := range network.HostsDescending() {
+		if ip.String() != "10.255.255.254" {
+			t.Errorf("HostsDescending() first = %v, want 10.255.255.254", ip)
+		}
+
+		break
+	}
+}
+
+func TestNetwork_PointToPoint(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		wantA     string
+		wantB     string
+		wantError error
+	}{
+		{"10.0.0.0/30", "10.0.0.1", "10.0.0.2", nil},
+		{"10.0.0.6/30", "10.0.0.5", "10.0.0.6", nil},
+		{"10.0.0.0/31", "10.0.0.0", "10.0.0.1", nil},
+		{"10.0.0.0/29", "", "", ipv4.ErrInvalidPrefix},
+		{"10.0.0.0/32", "", "", ipv4.ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			a, b, err := network.PointToPoint()
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("PointToPoint() error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("PointToPoint() error = %v", err)
+			}
+
+			if a.String() != tt.wantA || b.String() != tt.wantB {
+				t.Errorf("PointToPoint() = %v, %v, want %s, %s", a, b, tt.wantA, tt.wantB)
+			}
+		})
+	}
+}
+
+func TestNetwork_FormattedTextLinkEndpoints(t *testing.T) {
+	network, err := ipv4.ParseCIDR("10.0.0.0/31")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output := network.FormattedText()
+	if !strings.HasSuffix(output, "\n      Note:\tlink endpoints 10.0.0.0, 10.0.0.1") {
+		t.Errorf("FormattedText() missing link endpoints note:\n%s", output)
+	}
+}
+
+func TestSpecialHostRoles(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want map[int]string
+	}{
+		{"10.0.0.255", map[int]string{24: "broadcast", 25: "broadcast", 26: "broadcast", 27: "broadcast", 28: "broadcast", 29: "broadcast", 30: "broadcast"}},
+		{"10.0.0.0", map[int]string{
+			8: "network", 9: "network", 10: "network", 11: "network", 12: "network", 13: "network", 14: "network",
+			15: "network", 16: "network", 17: "network", 18: "network", 19: "network", 20: "network", 21: "network",
+			22: "network", 23: "network", 24: "network", 25: "network", 26: "network", 27: "network", 28: "network",
+			29: "network", 30: "network",
+		}},
+		{"10.0.0.128", map[int]string{25: "network", 26: "network", 27: "network", 28: "network", 29: "network", 30: "network"}},
+		{"10.0.0.5", map[int]string{}},
+		{"10.0.0.6", map[int]string{}},
+		{"10.0.0.7", map[int]string{29: "broadcast", 30: "broadcast"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ipv4.SpecialHostRoles(net.ParseIP(tt.ip)); !maps.Equal(got, tt.want) {
+				t.Errorf("SpecialHostRoles(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+
+	if got := ipv4.SpecialHostRoles(net.ParseIP("2001:db8::")); got != nil {
+		t.Errorf("SpecialHostRoles(2001:db8::) = %v, want nil", got)
+	}
+}
+
+func TestNetwork_TotalAddresses(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want uint64
+	}{
+		{"10.0.0.0/24", 256},
+		{"10.0.0.0/31", 2},
+		{"10.0.0.1/32", 1},
+		{"0.0.0.0/0", 1 << 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.TotalAddresses(); got != tt.want {
+				t.Errorf("TotalAddresses() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_BitCounts(t *testing.T) {
+	tests := []struct {
+		cidr            string
+		wantNetworkBits int
+		wantHostBits    int
+	}{
+		{"10.0.0.0/26", 26, 6},
+		{"10.0.0.0/8", 8, 24},
+		{"10.0.0.1/32", 32, 0},
+		{"0.0.0.0/0", 0, 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			networkBits, hostBits := network.BitCounts()
+			if networkBits != tt.wantNetworkBits || hostBits != tt.wantHostBits {
+				t.Errorf("BitCounts() = %d, %d, want %d, %d", networkBits, hostBits, tt.wantNetworkBits, tt.wantHostBits)
+			}
+		})
+	}
+}
+
+func TestPrefixForHosts(t *testing.T) {
+	tests := []struct {
+		hosts   int
+		want    int
+		wantErr error
+	}{
+		{1, 32, nil},
+		{2, 31, nil},
+		{3, 29, nil},
+		{6, 29, nil},
+		{7, 28, nil},
+		{254, 24, nil},
+		{255, 23, nil},
+		{500, 23, nil},
+		{1<<32 - 2, 0, nil},
+		{1<<32 - 1, 0, ipv4.ErrHostOutOfRange},
+		{0, 0, ipv4.ErrHostOutOfRange},
+		{-5, 0, ipv4.ErrHostOutOfRange},
+	}
+
+	for _, tt := range tests {
+		got, err := ipv4.PrefixForHosts(tt.hosts)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("PrefixForHosts(%d) error = %v, want %v", tt.hosts, err, tt.wantErr)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("PrefixForHosts(%d) = %d, want %d", tt.hosts, got, tt.want)
+		}
+	}
+}
+
+func TestNetwork_UsableHosts(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want uint64
+	}{
+		{"10.0.0.0/24", 254},
+		{"10.0.0.0/30", 2},
+		{"10.0.0.0/31", 2},
+		{"10.0.0.1/32", 1},
+		{"0.0.0.0/0", 1<<32 - 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if got := network.UsableHosts(); got != tt.want {
+				t.Errorf("UsableHosts() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
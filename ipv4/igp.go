@@ -0,0 +1,16 @@
+package ipv4
+
+import "fmt"
+
+// OSPFStatement returns the Cisco-style OSPF statement that enables the network in area, e.g.
+// "network 10.0.0.0 0.0.0.255 area 0" for 10.0.0.0/24. OSPF matches interfaces with a wildcard
+// mask, the prefix's complement, rather than a netmask. Calculate must have been called.
+func (n *Network) OSPFStatement(area int) string {
+	return fmt.Sprintf("%s area %d", n.EIGRPStatement(), area)
+}
+
+// EIGRPStatement returns the Cisco-style EIGRP statement that enables the network, e.g.
+// "network 10.0.0.0 0.0.0.255" for 10.0.0.0/24. Calculate must have been called.
+func (n *Network) EIGRPStatement() string {
+	return fmt.Sprintf("network %s %s", n.Network, n.Wildcard)
+}
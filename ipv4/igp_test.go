@@ -0,0 +1,41 @@
+package ipv4_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_IGPStatements(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		area      int
+		wantOSPF  string
+		wantEIGRP string
+	}{
+		{"10.0.0.0/24", 0, "network 10.0.0.0 0.0.0.255 area 0", "network 10.0.0.0 0.0.0.255"},
+		{"172.16.5.9/22", 1, "network 172.16.4.0 0.0.3.255 area 1", "network 172.16.4.0 0.0.3.255"},
+		{"192.168.1.1/32", 0, "network 192.168.1.1 0.0.0.0 area 0", "network 192.168.1.1 0.0.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if got := network.OSPFStatement(tt.area); got != tt.wantOSPF {
+				t.Errorf("OSPFStatement() = %q, want %q", got, tt.wantOSPF)
+			}
+
+			if got := network.EIGRPStatement(); got != tt.wantEIGRP {
+				t.Errorf("EIGRPStatement() = %q, want %q", got, tt.wantEIGRP)
+			}
+		})
+	}
+}
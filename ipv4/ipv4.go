@@ -1,8 +1,14 @@
 package ipv4
 
 import (
+	"encoding/binary"
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
+
+	"github.com/ronny/ripcalc/internal/layout"
+	"github.com/ronny/ripcalc/internal/trie"
 )
 
 type addressType int
@@ -14,6 +20,9 @@ const (
 	addressTypeLinkLocal
 	addressTypeLoopback
 	addressTypeMulticast
+	addressTypeDocumentation
+	addressTypeThisNetwork
+	addressTypeDefaultRoute
 )
 
 func (at addressType) String() string {
@@ -30,17 +39,50 @@ func (at addressType) String() string {
 		return "Loopback"
 	case addressTypeMulticast:
 		return "Multicast"
+	case addressTypeDocumentation:
+		return "Documentation"
+	case addressTypeThisNetwork:
+		return "This Network (RFC 1122)"
+	case addressTypeDefaultRoute:
+		return "Default Route"
 	default:
 		return "Unknown"
 	}
 }
 
+// jsonName returns the identifier used for the address type in JSON output
+func (at addressType) jsonName() string {
+	switch at {
+	case addressTypePublic:
+		return "PUBLIC"
+	case addressTypePrivate:
+		return "PRIVATE"
+	case addressTypeSharedAddressSpace:
+		return "SHARED_ADDRESS_SPACE"
+	case addressTypeLinkLocal:
+		return "LINK_LOCAL"
+	case addressTypeLoopback:
+		return "LOOPBACK"
+	case addressTypeMulticast:
+		return "MULTICAST"
+	case addressTypeDocumentation:
+		return "DOCUMENTATION"
+	case addressTypeThisNetwork:
+		return "THIS_NETWORK"
+	case addressTypeDefaultRoute:
+		return "DEFAULT_ROUTE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 type addressRange struct {
 	network *net.IPNet
 	typ     addressType
 }
 
 var specialRanges = []addressRange{
+	{mustParseCIDR("0.0.0.0/8"), addressTypeThisNetwork},
 	{mustParseCIDR("192.168.0.0/16"), addressTypePrivate},
 	{mustParseCIDR("172.16.0.0/12"), addressTypePrivate},
 	{mustParseCIDR("10.0.0.0/8"), addressTypePrivate},
@@ -48,6 +90,21 @@ var specialRanges = []addressRange{
 	{mustParseCIDR("169.254.0.0/16"), addressTypeLinkLocal},
 	{mustParseCIDR("127.0.0.0/8"), addressTypeLoopback},
 	{mustParseCIDR("224.0.0.0/4"), addressTypeMulticast},
+	{mustParseCIDR("192.0.2.0/24"), addressTypeDocumentation},
+	{mustParseCIDR("198.51.100.0/24"), addressTypeDocumentation},
+	{mustParseCIDR("203.0.113.0/24"), addressTypeDocumentation},
+}
+
+// specialRangeTrie indexes specialRanges for longest-prefix-match lookups
+var specialRangeTrie = newRangeTrie(specialRanges)
+
+func newRangeTrie(ranges []addressRange) *trie.Trie[addressType] {
+	t := &trie.Trie[addressType]{}
+	for _, r := range ranges {
+		t.InsertNet(r.network, r.typ)
+	}
+
+	return t
 }
 
 func mustParseCIDR(cidr string) *net.IPNet {
@@ -59,6 +116,15 @@ func mustParseCIDR(cidr string) *net.IPNet {
 	return network
 }
 
+// FormatOptions controls optional parts of the formatted text output
+type FormatOptions struct {
+	// NoClass omits the class and address type, and skips classification in Calculate
+	NoClass bool
+	// BinaryWrap breaks the binary representation onto a new line after this many groups, 0 keeps
+	// it on one line
+	BinaryWrap int
+}
+
 type Network struct {
 	Address      net.IP
 	PrefixLength int
@@ -71,9 +137,22 @@ type Network struct {
 	HostCount    uint32
 	Class        string
 	Type         string
+	Format       FormatOptions
+	// CustomRanges are consulted before the built-in ranges when classifying the address type
+	CustomRanges []CustomRange
 }
 
 func ParseCIDR(cidr string) (*Network, error) {
+	cidr, err := NormalizeMask(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("NormalizeMask: %w", err)
+	}
+
+	cidr, err = NormalizeAbbreviated(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("NormalizeAbbreviated: %w", err)
+	}
+
 	ip, ipNet, err := net.ParseCIDR(cidr)
 	if err != nil {
 		return nil, fmt.Errorf("net.ParseCIDR: %w", err)
@@ -91,62 +170,242 @@ func ParseCIDR(cidr string) (*Network, error) {
 	}, nil
 }
 
+// newNetwork returns a calculated network for the given base address and prefix length
+func newNetwork(addr uint32, prefixLen int) (*Network, error) {
+	n := &Network{
+		Address:      fromUint32(addr),
+		PrefixLength: prefixLen,
+	}
+
+	if err := n.Calculate(); err != nil {
+		return nil, fmt.Errorf("Calculate: %w", err)
+	}
+
+	return n, nil
+}
+
 func (n *Network) String() string {
 	return fmt.Sprintf("%s/%d", n.Address, n.PrefixLength)
 }
 
+// CanonicalCIDR returns the network address and prefix length with any host bits cleared, e.g.
+// "10.0.0.0/24" for 10.0.0.5/24
+func (n *Network) CanonicalCIDR() string {
+	first, _ := n.bounds()
+
+	return fmt.Sprintf("%s/%d", fromUint32(first), n.PrefixLength)
+}
+
+// HostCountString returns the usable host count as a decimal string, the form it shares with
+// IPv6 counts too large for an integer type. Calculate must have been called.
+func (n *Network) HostCountString() string {
+	return strconv.FormatUint(uint64(n.HostCount), 10)
+}
+
+// ClassType returns the class and address type as shown after the host count, e.g. "Class C,
+// Private Internet", or an empty string if the NoClass format option is set. Calculate must have
+// been called.
+func (n *Network) ClassType() string {
+	return n.classSummary()
+}
+
 func (n *Network) Calculate() error {
 	if n.Address == nil {
 		return fmt.Errorf("%w: address is nil", ErrInvalidAddress)
 	}
 
+	if n.PrefixLength < 0 || n.PrefixLength > 32 {
+		return fmt.Errorf("%w: /%d is outside /0 to /32", ErrInvalidPrefix, n.PrefixLength)
+	}
+
+	// Classification and the binary output index the address as 4 bytes, so a 16-byte form, e.g.
+	// from net.ParseIP, is narrowed. It's copied so no field shares the caller's backing array.
+	address := n.Address.To4()
+	if address == nil {
+		return fmt.Errorf("%w: %s is not an IPv4 address", ErrInvalidAddress, n.Address)
+	}
+
+	n.Address = append(net.IP(nil), address...)
+
 	n.Netmask = net.CIDRMask(n.PrefixLength, 32)
 	n.Wildcard = invertMask(net.IP(n.Netmask))
 	n.Network = n.Address.Mask(n.Netmask)
 	n.Broadcast = calculateBroadcast(n.Network, n.Wildcard)
 	n.HostMin, n.HostMax = calculateHostRange(n.Network, n.Broadcast)
 	n.HostCount = calculateHostCount(n.PrefixLength)
-	n.Class = classifyAddress(n.Address)
-	n.Type = classifyAddressType(n.Address).String()
+
+	if !n.Format.NoClass {
+		n.Class = classifyAddress(n.Address)
+		n.Type = classifyAddressType(n.Address).String()
+
+		if label, ok := n.customLabel(); ok {
+			n.Type = label
+		}
+
+		// The default route covers every address, so a class or range type would be misleading
+		if n.PrefixLength == 0 {
+			n.Class, n.Type = "", addressTypeDefaultRoute.String()
+		}
+	}
 
 	return nil
 }
 
 func (n *Network) FormattedText() string {
-	addressBinary := FormatBinaryWithMask(n.Address, n.PrefixLength)
-	netmaskBinary := FormatBinaryWithMask(net.IP(n.Netmask), n.PrefixLength)
-	wildcardBinary := FormatBinaryWithMask(n.Wildcard, n.PrefixLength)
-	networkBinary := FormatBinaryWithMask(n.Network, n.PrefixLength)
-	hostMinBinary := FormatBinaryWithMask(n.HostMin, n.PrefixLength)
-	hostMaxBinary := FormatBinaryWithMask(n.HostMax, n.PrefixLength)
-	broadcastBinary := FormatBinaryWithMask(n.Broadcast, n.PrefixLength)
-
-	typeStr := n.Type
-
-	return fmt.Sprintf(
+	addressBinary := n.wrapBinary(FormatBinaryWithMask(n.Address, n.PrefixLength))
+	netmaskBinary := n.wrapBinary(FormatBinaryWithMask(net.IP(n.Netmask), n.PrefixLength))
+	wildcardBinary := n.wrapBinary(FormatBinaryWithMask(n.Wildcard, n.PrefixLength))
+	networkBinary := n.wrapBinary(FormatBinaryWithMask(n.Network, n.PrefixLength))
+	hostMinBinary := n.wrapBinary(FormatBinaryWithMask(n.HostMin, n.PrefixLength))
+	hostMaxBinary := n.wrapBinary(FormatBinaryWithMask(n.HostMax, n.PrefixLength))
+	broadcastBinary := n.wrapBinary(FormatBinaryWithMask(n.Broadcast, n.PrefixLength))
+
+	return layout.DrawSeparator(fmt.Sprintf(
 		""+
 			"   Address:\t%-20s\t%s\n"+
 			"    Prefix:\t%-20s\n"+
 			"   Netmask:\t%-20s\t%s\n"+
 			"  Wildcard:\t%-20s\t%s\n"+
-			"----------------------------------------------------------------------------\n"+
+			"%s\n"+
 			"   Network:\t%-20s\t%s\n"+
 			"First host:\t%-20s\t%s\n"+
 			" Last host:\t%-20s\t%s\n"+
 			" Broadcast:\t%-20s\t%s\n"+
-			"Host count:\t%-20d\tClass %s, %s",
+			"Host count:\t%s",
 		n.Address.String(), addressBinary,
 		fmt.Sprintf("/%d", n.PrefixLength),
 		net.IP(n.Netmask).String(), netmaskBinary,
 		n.Wildcard.String(), wildcardBinary,
+		layout.SeparatorPlaceholder,
 		fmt.Sprintf("%s/%d", n.Network.String(), n.PrefixLength), networkBinary,
 		n.HostMin.String(), hostMinBinary,
 		n.HostMax.String(), hostMaxBinary,
 		n.Broadcast.String(), broadcastBinary,
-		n.HostCount, n.Class, typeStr,
+		n.hostCountSummary(),
+	) + n.scopeRow() + n.notes())
+}
+
+func (n *Network) FormattedTextNoBinary() string {
+	return layout.Rows([]layout.Row{
+		{Label: "Address", Value: n.Address.String()},
+		{Label: "Prefix", Value: fmt.Sprintf("/%d", n.PrefixLength)},
+		{Label: "Netmask", Value: net.IP(n.Netmask).String()},
+		{Label: "Wildcard", Value: n.Wildcard.String()},
+		layout.Separator,
+		{Label: "Network", Value: fmt.Sprintf("%s/%d", n.Network.String(), n.PrefixLength)},
+		{Label: "First host", Value: n.HostMin.String()},
+		{Label: "Last host", Value: n.HostMax.String()},
+		{Label: "Broadcast", Value: n.Broadcast.String()},
+		{Label: "Host count", Value: fmt.Sprintf("%d", n.HostCount), Extra: n.classSummary()},
+	}, n.scopeRow()+n.notes())
+}
+
+// HCLText returns the network as a Terraform/HCL object literal, quoting strings and leaving
+// numbers bare
+func (n *Network) HCLText() string {
+	return fmt.Sprintf(
+		`{ cidr = "%s/%d", netmask = "%s", network = "%s", broadcast = "%s", hosts = %d }`,
+		n.Network, n.PrefixLength, net.IP(n.Netmask), n.Network, n.Broadcast, n.HostCount,
 	)
 }
 
+// HasHostBits reports whether the address has bits set below the prefix boundary, i.e. it isn't
+// the network address
+func (n *Network) HasHostBits() bool {
+	return !n.Address.Equal(n.Network)
+}
+
+// CheckNetworkAddress returns ErrInvalidAddress if the address has host bits set, naming the
+// network it should be written as, e.g. 10.0.0.0/24 for 10.0.0.5/24, so configs can be linted to
+// express networks cleanly. Calculate must have been called.
+func (n *Network) CheckNetworkAddress() error {
+	if n.HasHostBits() {
+		return fmt.Errorf("%w: %s has host bits set; the network is %s", ErrInvalidAddress, n, n.CanonicalCIDR())
+	}
+
+	return nil
+}
+
+// scopeRow returns the Scope line appended after the host count, or an empty string if the
+// NoClass format option is set or the network is the default route, which spans every scope
+func (n *Network) scopeRow() string {
+	if n.Format.NoClass || n.PrefixLength == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n     Scope:\t%s", n.Scope())
+}
+
+// notes returns informational lines appended to the formatted text
+func (n *Network) notes() string {
+	var b strings.Builder
+
+	if n.HasHostBits() {
+		fmt.Fprintf(&b, "\n      Note:\thost bits set; network is %s/%d", n.Network, n.PrefixLength)
+	}
+
+	if a, z, err := n.PointToPoint(); err == nil {
+		fmt.Fprintf(&b, "\n      Note:\tlink endpoints %s, %s", a, z)
+	}
+
+	if n.PrefixLength == 0 {
+		first, last := n.bounds()
+		fmt.Fprintf(&b, "\n      Note:\tdefault route spanning %s to %s", fromUint32(first), fromUint32(last))
+	}
+
+	return b.String()
+}
+
+// wrapBinary splits a binary representation after every BinaryWrap groups, indenting the
+// continuation lines under the binary column
+func (n *Network) wrapBinary(binary string) string {
+	if n.Format.BinaryWrap <= 0 {
+		return binary
+	}
+
+	groups := strings.Split(binary, ".")
+
+	var lines []string
+	for len(groups) > n.Format.BinaryWrap {
+		lines = append(lines, strings.Join(groups[:n.Format.BinaryWrap], ".")+".")
+		groups = groups[n.Format.BinaryWrap:]
+	}
+
+	lines = append(lines, strings.Join(groups, "."))
+
+	// Skip the label and value columns: the label tab stop, the padded value and its tab
+	return strings.Join(lines, "\n\t\t"+strings.Repeat(" ", 20)+"\t")
+}
+
+// hostCountSummary returns the host count followed by the class and address type, unless the
+// NoClass format option is set
+func (n *Network) hostCountSummary() string {
+	if n.Format.NoClass {
+		return fmt.Sprintf("%d", n.HostCount)
+	}
+
+	return fmt.Sprintf("%-20d\t%s", n.HostCount, n.classSummary())
+}
+
+// classSummary returns the class and address type shown after the host count, or an empty string
+// if the NoClass format option is set
+func (n *Network) classSummary() string {
+	if n.Format.NoClass {
+		return ""
+	}
+
+	if n.PrefixLength == 0 {
+		return n.Type + " (all addresses)"
+	}
+
+	// Name the group's scope as IPv6 does, unless a custom range has relabelled the type
+	if scope := MulticastScope(n.Address); scope != "" && n.Type == addressTypeMulticast.String() {
+		return fmt.Sprintf("Class %s, %s %s", n.Class, n.Type, scope)
+	}
+
+	return fmt.Sprintf("Class %s, %s", n.Class, n.Type)
+}
+
 func invertMask(mask net.IP) net.IP {
 	wildcard := make(net.IP, 4)
 	for i := range 4 {
@@ -207,15 +466,39 @@ func classifyAddress(ip net.IP) string {
 }
 
 func classifyAddressType(ip net.IP) addressType {
-	for _, r := range specialRanges {
-		if r.network.Contains(ip) {
-			return r.typ
-		}
+	if typ, ok := specialRangeTrie.Lookup(ip.To4()); ok {
+		return typ
 	}
 
 	return addressTypePublic
 }
 
+// bounds returns the first and last addresses of the network as integers, derived from the address
+// and prefix length so it doesn't depend on Calculate having been called
+func (n *Network) bounds() (uint32, uint32) {
+	hostMask := uint32(0xffffffff)
+	if n.PrefixLength > 0 {
+		hostMask = ^(hostMask << (32 - n.PrefixLength))
+	}
+
+	first := toUint32(n.Address) &^ hostMask
+
+	return first, first | hostMask
+}
+
+// toUint32 converts a 4-byte IPv4 address to its integer value
+func toUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+// fromUint32 converts an integer value to a 4-byte IPv4 address
+func fromUint32(v uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, v)
+
+	return ip
+}
+
 func FormatBinary(ip net.IP) string {
 	if len(ip) != 4 {
 		return ""
@@ -1,6 +1,8 @@
 package ipv4_test
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"strings"
 	"testing"
@@ -446,3 +448,390 @@ func TestReservedAddressClassification(t *testing.T) {
 		t.Errorf("Type = %v, want Public Internet", network.Type)
 	}
 }
+
+func TestNetwork_FormattedTextNoBinary(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.0.1/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output := network.FormattedTextNoBinary()
+
+	expectedParts := []string{
+		"192.168.0.1",
+		"/24",
+		"255.255.255.0",
+		"0.0.0.255",
+		"192.168.0.0/24",
+		"192.168.0.254",
+		"192.168.0.255",
+		"254",
+		"Class C",
+		"Private Internet",
+	}
+
+	for _, part := range expectedParts {
+		if !strings.Contains(output, part) {
+			t.Errorf("FormattedTextNoBinary() missing expected part: %q", part)
+		}
+	}
+
+	unexpectedParts := []string{
+		"11000000",
+		"11111111",
+		"00000000",
+	}
+
+	for _, part := range unexpectedParts {
+		if strings.Contains(output, part) {
+			t.Errorf("FormattedTextNoBinary() contains binary part: %q", part)
+		}
+	}
+}
+
+func TestNetwork_FormattedTextSeparatorWidth(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.0.1/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	outputs := map[string]string{
+		"FormattedText":         network.FormattedText(),
+		"FormattedTextNoBinary": network.FormattedTextNoBinary(),
+	}
+
+	for name, output := range outputs {
+		t.Run(name, func(t *testing.T) {
+			separator, longest := separatorAndLongestRow(output)
+			if separator == 0 {
+				t.Fatalf("%s() has no separator line:\n%s", name, output)
+			}
+
+			if separator != longest {
+				t.Errorf("%s() separator width = %d, want %d", name, separator, longest)
+			}
+		})
+	}
+}
+
+// separatorAndLongestRow returns the width of the dashed separator line and the display width
+// (with 8-column tab stops) of the longest content row
+func separatorAndLongestRow(output string) (int, int) {
+	separator, longest := 0, 0
+
+	for line := range strings.SplitSeq(output, "\n") {
+		if strings.Trim(line, "-") == "" {
+			separator = len(line)
+			continue
+		}
+
+		width := 0
+
+		for _, r := range strings.TrimRight(line, " ") {
+			if r == '\t' {
+				width += 8 - width%8
+			} else {
+				width++
+			}
+		}
+
+		longest = max(longest, width)
+	}
+
+	return separator, longest
+}
+
+func TestNetwork_CalculateInvalidPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefixLength int
+	}{
+		{
+			name:         "prefix too long",
+			prefixLength: 200,
+		},
+		{
+			name:         "negative prefix",
+			prefixLength: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := &ipv4.Network{
+				Address:      net.ParseIP("10.0.0.1").To4(),
+				PrefixLength: tt.prefixLength,
+			}
+
+			err := network.Calculate()
+			if !errors.Is(err, ipv4.ErrInvalidPrefix) {
+				t.Errorf("Calculate() error = %v, want %v", err, ipv4.ErrInvalidPrefix)
+			}
+		})
+	}
+}
+
+func TestNetwork_FormattedTextNoClass(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.0.1/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	network.Format.NoClass = true
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if network.Class != "" || network.Type != "" {
+		t.Errorf("Calculate() classified address with NoClass: Class = %q, Type = %q", network.Class, network.Type)
+	}
+
+	outputs := map[string]string{
+		"FormattedText":         network.FormattedText(),
+		"FormattedTextNoBinary": network.FormattedTextNoBinary(),
+	}
+
+	for name, output := range outputs {
+		if strings.Contains(output, "Class") || strings.Contains(output, "Private Internet") {
+			t.Errorf("%s() contains classification:\n%s", name, output)
+		}
+
+		if !strings.Contains(output, "Host count:\t254") {
+			t.Errorf("%s() missing host count:\n%s", name, output)
+		}
+	}
+}
+
+func TestNetwork_HCLText(t *testing.T) {
+	network, err := ipv4.ParseCIDR("10.0.0.5/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	want := `{ cidr = "10.0.0.0/24", netmask = "255.255.255.0", network = "10.0.0.0", ` +
+		`broadcast = "10.0.0.255", hosts = 254 }`
+
+	if got := network.HCLText(); got != want {
+		t.Errorf("HCLText() = %v, want %v", got, want)
+	}
+}
+
+func TestNetwork_HostBitsNotice(t *testing.T) {
+	tests := []struct {
+		name       string
+		cidr       string
+		wantNotice bool
+	}{
+		{
+			name:       "host bits set",
+			cidr:       "10.0.0.5/24",
+			wantNotice: true,
+		},
+		{
+			name:       "aligned network",
+			cidr:       "10.0.0.0/24",
+			wantNotice: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			err = network.Calculate()
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if network.HasHostBits() != tt.wantNotice {
+				t.Errorf("HasHostBits() = %v, want %v", network.HasHostBits(), tt.wantNotice)
+			}
+
+			err = network.CheckNetworkAddress()
+			if tt.wantNotice != errors.Is(err, ipv4.ErrInvalidAddress) {
+				t.Errorf("CheckNetworkAddress() error = %v, want ErrInvalidAddress %v", err, tt.wantNotice)
+			}
+
+			for _, output := range []string{network.FormattedText(), network.FormattedTextNoBinary()} {
+				hasNotice := strings.Contains(output, "host bits set; network is 10.0.0.0/24")
+				if hasNotice != tt.wantNotice {
+					t.Errorf("notice present = %v, want %v\n%s", hasNotice, tt.wantNotice, output)
+				}
+			}
+		})
+	}
+}
+
+func TestNetwork_FormattedTextBinaryWrap(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.0.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	network.Format.BinaryWrap = 2
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	lines := strings.Split(network.FormattedText(), "\n")
+
+	if want := "   Address:\t192.168.0.0         \t11000000.10101000."; lines[0] != want {
+		t.Errorf("first line = %q, want %q", lines[0], want)
+	}
+
+	if want := "\t\t" + strings.Repeat(" ", 20) + "\t00000000. 00000000"; lines[1] != want {
+		t.Errorf("continuation line = %q, want %q", lines[1], want)
+	}
+
+	if first, next := binaryColumn(lines[0]), binaryColumn(lines[1]); first != 40 || next != first {
+		t.Errorf("binary columns = %d and %d, want both at 40", first, next)
+	}
+
+	separator, longest := separatorAndLongestRow(strings.Join(lines, "\n"))
+	if separator != longest {
+		t.Errorf("separator length = %d, longest row = %d", separator, longest)
+	}
+}
+
+// displayWidth returns the columns s occupies with 8-column tab stops
+func displayWidth(s string) int {
+	width := 0
+
+	for _, r := range s {
+		if r == '\t' {
+			width += 8 - width%8
+		} else {
+			width++
+		}
+	}
+
+	return width
+}
+
+// binaryColumn returns the column the binary representation after a row's last tab starts at
+func binaryColumn(line string) int {
+	return displayWidth(line[:strings.LastIndex(line, "\t")+1])
+}
+
+func TestNetwork_DefaultRoute(t *testing.T) {
+	network, err := ipv4.ParseCIDR("0.0.0.0/0")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if network.Class != "" || network.Type != "Default Route" {
+		t.Errorf("Class, Type = %q, %q, want \"\", \"Default Route\"", network.Class, network.Type)
+	}
+
+	output := network.FormattedTextNoBinary()
+	for _, element := range []string{"Default Route (all addresses)", "spanning 0.0.0.0 to 255.255.255.255"} {
+		if !strings.Contains(output, element) {
+			t.Errorf("Output missing %q\nFull output:\n%s", element, output)
+		}
+	}
+
+	if strings.Contains(output, "Class A") {
+		t.Errorf("Default route shouldn't report an address class\nFull output:\n%s", output)
+	}
+}
+
+func TestThisNetworkClassification(t *testing.T) {
+	for _, cidr := range []string{"0.0.0.1/32", "0.0.0.5/8", "0.255.255.255/32"} {
+		t.Run(cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if network.Type != "This Network (RFC 1122)" {
+				t.Errorf("Type = %v, want This Network (RFC 1122)", network.Type)
+			}
+		})
+	}
+}
+
+func TestNetwork_CalculateFieldsIndependent(t *testing.T) {
+	for _, address := range []net.IP{net.ParseIP("192.168.1.77").To4(), net.ParseIP("192.168.1.77")} {
+		t.Run(fmt.Sprintf("%d-byte address", len(address)), func(t *testing.T) {
+			network := &ipv4.Network{Address: address, PrefixLength: 24}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			first := network.FormattedText()
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("second Calculate() error = %v", err)
+			}
+
+			if second := network.FormattedText(); second != first {
+				t.Errorf("FormattedText() changed after a second Calculate():\n%s\nwant\n%s", second, first)
+			}
+
+			if network.Class != "C" {
+				t.Errorf("Class = %q, want C", network.Class)
+			}
+
+			// Scribbling over every derived field must leave the address and the others alone
+			for _, field := range []net.IP{network.Network, network.Broadcast, network.HostMin, network.HostMax, network.Wildcard, net.IP(network.Netmask)} {
+				for i := range field {
+					field[i] = 0xAA
+				}
+			}
+
+			if got := network.Address.String(); got != "192.168.1.77" {
+				t.Errorf("Address = %s after changing the derived fields, want 192.168.1.77", got)
+			}
+
+			if got := address.String(); got != "192.168.1.77" {
+				t.Errorf("caller's address = %s after changing the derived fields, want 192.168.1.77", got)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("third Calculate() error = %v", err)
+			}
+
+			if third := network.FormattedText(); third != first {
+				t.Errorf("FormattedText() after recalculating =\n%s\nwant\n%s", third, first)
+			}
+		})
+	}
+}
+
+func TestNetwork_CalculateIPv6Address(t *testing.T) {
+	network := &ipv4.Network{Address: net.ParseIP("2001:db8::1"), PrefixLength: 24}
+
+	if err := network.Calculate(); !errors.Is(err, ipv4.ErrInvalidAddress) {
+		t.Errorf("Calculate() error = %v, want %v", err, ipv4.ErrInvalidAddress)
+	}
+}
@@ -0,0 +1,104 @@
+package ipv4
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// SchemaURL identifies the JSON schema the marshalled output follows
+const SchemaURL = "https://github.com/ronny/ripcalc/blob/main/schema/ipv4-v1.json"
+
+type jsonUsableHosts struct {
+	Min   string `json:"min"`
+	Max   string `json:"max"`
+	Count uint32 `json:"count"`
+}
+
+type jsonNetwork struct {
+	Address      string          `json:"address"`
+	PrefixLength string          `json:"prefix_length"`
+	Broadcast    string          `json:"broadcast"`
+	UsableHosts  jsonUsableHosts `json:"usable_hosts"`
+	Class        string          `json:"class,omitempty"`
+	Type         string          `json:"type,omitempty"`
+	Label        string          `json:"label,omitempty"`
+}
+
+type jsonDocument struct {
+	Schema   string      `json:"$schema"`
+	Address  string      `json:"address"`
+	Netmask  string      `json:"netmask"`
+	Wildcard string      `json:"wildcard"`
+	Network  jsonNetwork `json:"network"`
+}
+
+// MarshalJSON encodes a calculated network in the documented ipv4-v1 schema. The class and type
+// are left out when the NoClass format option is set. Addresses in a custom range have the CUSTOM
+// type and the range's label.
+func (n *Network) MarshalJSON() ([]byte, error) {
+	doc := jsonDocument{
+		Schema:   SchemaURL,
+		Address:  n.Address.String(),
+		Netmask:  net.IP(n.Netmask).String(),
+		Wildcard: n.Wildcard.String(),
+		Network: jsonNetwork{
+			Address:      n.Network.String(),
+			PrefixLength: strconv.Itoa(n.PrefixLength),
+			Broadcast:    n.Broadcast.String(),
+			UsableHosts: jsonUsableHosts{
+				Min:   n.HostMin.String(),
+				Max:   n.HostMax.String(),
+				Count: n.HostCount,
+			},
+		},
+	}
+
+	if !n.Format.NoClass {
+		doc.Network.Class = n.Class
+		doc.Network.Type = classifyAddressType(n.Address).jsonName()
+
+		if label, ok := n.customLabel(); ok {
+			doc.Network.Type, doc.Network.Label = "CUSTOM", label
+		}
+
+		if n.PrefixLength == 0 {
+			doc.Network.Class, doc.Network.Type, doc.Network.Label = "", addressTypeDefaultRoute.jsonName(), ""
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON decodes a document written by MarshalJSON back into a calculated network, so a
+// later stage of a pipeline can read ripcalc's JSON output. Only the address and prefix length are
+// read and everything else is recalculated, keeping the network's format options; a document
+// without a class or type sets NoClass. It returns ErrInvalidAddress for another schema's document.
+func (n *Network) UnmarshalJSON(data []byte) error {
+	var doc jsonDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if doc.Schema != SchemaURL {
+		return fmt.Errorf("%w: $schema %q isn't %s", ErrInvalidAddress, doc.Schema, SchemaURL)
+	}
+
+	parsed, err := ParseCIDR(doc.Address + "/" + doc.Network.PrefixLength)
+	if err != nil {
+		return fmt.Errorf("ParseCIDR: %w", err)
+	}
+
+	n.Address, n.PrefixLength = parsed.Address, parsed.PrefixLength
+
+	if doc.Network.Class == "" && doc.Network.Type == "" {
+		n.Format.NoClass = true
+	}
+
+	if err := n.Calculate(); err != nil {
+		return fmt.Errorf("Calculate: %w", err)
+	}
+
+	return nil
+}
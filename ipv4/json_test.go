@@ -0,0 +1,110 @@
+package ipv4_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_MarshalJSON(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.0.1/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output, err := json.Marshal(network)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	expected := `{"$schema":"https://github.com/ronny/ripcalc/blob/main/schema/ipv4-v1.json",` +
+		`"address":"192.168.0.1","netmask":"255.255.255.0","wildcard":"0.0.0.255",` +
+		`"network":{"address":"192.168.0.0","prefix_length":"24","broadcast":"192.168.0.255",` +
+		`"usable_hosts":{"min":"192.168.0.1","max":"192.168.0.254","count":254},` +
+		`"class":"C","type":"PRIVATE"}}`
+
+	if string(output) != expected {
+		t.Errorf("json.Marshal() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestNetwork_MarshalJSONNoClass(t *testing.T) {
+	network, err := ipv4.ParseCIDR("8.8.8.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	network.Format.NoClass = true
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output, err := json.Marshal(network)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var doc struct {
+		Network map[string]any `json:"network"`
+	}
+
+	if err := json.Unmarshal(output, &doc); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	for _, key := range []string{"class", "type"} {
+		if _, ok := doc.Network[key]; ok {
+			t.Errorf("json.Marshal() should omit %q with NoClass: %s", key, output)
+		}
+	}
+}
+
+func TestNetwork_UnmarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		cidr    string
+		noClass bool
+	}{
+		{"192.168.0.1/24", false},
+		{"8.8.8.0/24", true},
+		{"0.0.0.0/0", false},
+	} {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.Format.NoClass = tt.noClass
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			output, err := json.Marshal(network)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			var decoded ipv4.Network
+			if err := json.Unmarshal(output, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			if decoded.FormattedText() != network.FormattedText() {
+				t.Errorf("round trip FormattedText() =\n%s\nwant\n%s", decoded.FormattedText(), network.FormattedText())
+			}
+		})
+	}
+
+	var decoded ipv4.Network
+	if err := json.Unmarshal([]byte(`{"$schema":"https://github.com/ronny/ripcalc/blob/main/schema/ipv6-v1.json","address":"2001:db8::"}`), &decoded); !errors.Is(err, ipv4.ErrInvalidAddress) {
+		t.Errorf("json.Unmarshal() of another schema error = %v, want ErrInvalidAddress", err)
+	}
+}
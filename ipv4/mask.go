@@ -0,0 +1,69 @@
+package ipv4
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// NormalizeMask rewrites CIDR notation written with a dotted or hex netmask as the prefix length,
+// e.g. "192.168.0.0/255.255.254.0" and "192.168.0.0/0xfffffe00" both become "192.168.0.0/23".
+// Input with a numeric prefix length is returned unchanged. It returns ErrInvalidPrefix for a
+// netmask whose ones aren't contiguous.
+func NormalizeMask(s string) (string, error) {
+	addr, mask, found := strings.Cut(s, "/")
+	if !found {
+		return s, nil
+	}
+
+	var ipMask net.IPMask
+
+	switch {
+	case strings.HasPrefix(mask, "0x") || strings.HasPrefix(mask, "0X"):
+		hexMask, err := ParseHexMask(mask)
+		if err != nil {
+			return "", err
+		}
+
+		ipMask = hexMask
+	case strings.Contains(mask, "."):
+		ip := net.ParseIP(mask).To4()
+		if ip == nil {
+			return "", fmt.Errorf("%w: invalid netmask %q", ErrInvalidPrefix, mask)
+		}
+
+		ipMask = net.IPMask(ip)
+	default:
+		return s, nil
+	}
+
+	ones, bits := ipMask.Size()
+	if bits == 0 {
+		return "", fmt.Errorf("%w: netmask %s isn't contiguous", ErrInvalidPrefix, mask)
+	}
+
+	return fmt.Sprintf("%s/%d", addr, ones), nil
+}
+
+// ParseHexMask parses a netmask written as 0x and eight hex digits, as Cisco show output and BSD
+// ifconfig print them, e.g. 0xffffff00 for 255.255.255.0. It returns ErrInvalidPrefix for the wrong
+// number of digits, anything that isn't hex, or a mask whose ones aren't contiguous.
+func ParseHexMask(s string) (net.IPMask, error) {
+	digits, ok := strings.CutPrefix(strings.ToLower(s), "0x")
+	if !ok || len(digits) != 8 {
+		return nil, fmt.Errorf("%w: hex netmask %q isn't 0x and 8 digits", ErrInvalidPrefix, s)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid hex netmask %q", ErrInvalidPrefix, s)
+	}
+
+	mask := net.IPMask(fromUint32(uint32(value)))
+	if _, bits := mask.Size(); bits == 0 {
+		return nil, fmt.Errorf("%w: netmask %s isn't contiguous", ErrInvalidPrefix, s)
+	}
+
+	return mask, nil
+}
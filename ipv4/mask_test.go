@@ -0,0 +1,109 @@
+package ipv4_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNormalizeMask(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      string
+		wantError error
+	}{
+		{"192.168.0.0/255.255.254.0", "192.168.0.0/23", nil},
+		{"10.0.0.0/255.0.0.0", "10.0.0.0/8", nil},
+		{"10.0.0.1/255.255.255.255", "10.0.0.1/32", nil},
+		{"0.0.0.0/0.0.0.0", "0.0.0.0/0", nil},
+		{"192.168.0.0/24", "192.168.0.0/24", nil},
+		{"192.168/16", "192.168/16", nil},
+		{"10.0.0.0/255.0.255.0", "", ipv4.ErrInvalidPrefix},
+		{"10.0.0.0/255.255.0", "", ipv4.ErrInvalidPrefix},
+		{"192.168.0.0/0xfffffe00", "192.168.0.0/23", nil},
+		{"10.0.0.0/0xFF000000", "10.0.0.0/8", nil},
+		{"10.0.0.0/0xff00ff00", "", ipv4.ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ipv4.NormalizeMask(tt.input)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("NormalizeMask() error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NormalizeMask() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("NormalizeMask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHexMask(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantOnes  int
+		wantError error
+	}{
+		{"0xffffff00", 24, nil},
+		{"0xFFFFFFFF", 32, nil},
+		{"0x00000000", 0, nil},
+		{"0xfffffffc", 30, nil},
+		{"0xff00ff00", 0, ipv4.ErrInvalidPrefix},
+		{"0xffffff", 0, ipv4.ErrInvalidPrefix},
+		{"0xffffff000", 0, ipv4.ErrInvalidPrefix},
+		{"0xfffffgg0", 0, ipv4.ErrInvalidPrefix},
+		{"ffffff00", 0, ipv4.ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			mask, err := ipv4.ParseHexMask(tt.input)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("ParseHexMask() error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseHexMask() error = %v", err)
+			}
+
+			if ones, _ := mask.Size(); ones != tt.wantOnes {
+				t.Errorf("ParseHexMask() = /%d, want /%d", ones, tt.wantOnes)
+			}
+		})
+	}
+}
+
+func TestParseCIDR_MaskBinaryBoundary(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.0.0/255.255.254.0")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if network.PrefixLength != 23 {
+		t.Fatalf("PrefixLength = %d, want 23", network.PrefixLength)
+	}
+
+	want := "11111111.11111111.1111111 0.00000000"
+	if got := ipv4.FormatBinaryWithMask(net.IP(network.Netmask), network.PrefixLength); got != want {
+		t.Errorf("FormatBinaryWithMask() = %q, want %q", got, want)
+	}
+}
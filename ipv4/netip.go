@@ -0,0 +1,61 @@
+package ipv4
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// FromAddr returns an uncalculated network for the address and prefix length, the net/netip
+// counterpart of ParseCIDR. IPv4-mapped IPv6 addresses are unmapped.
+func FromAddr(a netip.Addr, prefix int) (*Network, error) {
+	a = a.Unmap()
+	if !a.Is4() {
+		return nil, fmt.Errorf("%w: %s is not an IPv4 address", ErrInvalidAddress, a)
+	}
+
+	if prefix < 0 || prefix > 32 {
+		return nil, fmt.Errorf("%w: /%d is outside 0-32", ErrInvalidPrefix, prefix)
+	}
+
+	return &Network{
+		Address:      a.AsSlice(),
+		PrefixLength: prefix,
+	}, nil
+}
+
+// FromPrefix returns an uncalculated network for the prefix, keeping any host bits in its address
+func FromPrefix(p netip.Prefix) (*Network, error) {
+	if !p.IsValid() {
+		return nil, fmt.Errorf("%w: invalid prefix %s", ErrInvalidPrefix, p)
+	}
+
+	return FromAddr(p.Addr(), p.Bits())
+}
+
+// Addr returns the address as a netip.Addr, or the zero Addr if it isn't set
+func (n *Network) Addr() netip.Addr {
+	addr, ok := netip.AddrFromSlice(n.Address)
+	if !ok {
+		return netip.Addr{}
+	}
+
+	return addr
+}
+
+// Prefix returns the address and prefix length as a netip.Prefix, keeping any host bits
+func (n *Network) Prefix() netip.Prefix {
+	return netip.PrefixFrom(n.Addr(), n.PrefixLength)
+}
+
+// IsValidCIDR reports whether ParseCIDR would accept s, without building a Network. Plain
+// address/prefix notation is checked without allocating; masks and abbreviated addresses fall back
+// to ParseCIDR.
+func IsValidCIDR(s string) bool {
+	if p, err := netip.ParsePrefix(s); err == nil {
+		return p.Addr().Is4()
+	}
+
+	_, err := ParseCIDR(s)
+
+	return err == nil
+}
@@ -0,0 +1,108 @@
+package ipv4_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestFromPrefix_RoundTrip(t *testing.T) {
+	for _, s := range []string{"192.168.0.0/24", "10.1.2.3/8", "0.0.0.0/0", "255.255.255.255/32"} {
+		t.Run(s, func(t *testing.T) {
+			prefix := netip.MustParsePrefix(s)
+
+			network, err := ipv4.FromPrefix(prefix)
+			if err != nil {
+				t.Fatalf("FromPrefix() error = %v", err)
+			}
+
+			if network.String() != s {
+				t.Errorf("String() = %q, want %q", network.String(), s)
+			}
+
+			if got := network.Prefix(); got != prefix {
+				t.Errorf("Prefix() = %v, want %v", got, prefix)
+			}
+
+			if got := network.Addr(); got != prefix.Addr() {
+				t.Errorf("Addr() = %v, want %v", got, prefix.Addr())
+			}
+
+			parsed, err := ipv4.ParseCIDR(s)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := parsed.Prefix(); got != prefix {
+				t.Errorf("ParseCIDR().Prefix() = %v, want %v", got, prefix)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Errorf("Calculate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFromAddr_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		addr      netip.Addr
+		prefix    int
+		wantError error
+	}{
+		{"IPv6 address", netip.MustParseAddr("2001:db8::1"), 64, ipv4.ErrInvalidAddress},
+		{"prefix too long", netip.MustParseAddr("10.0.0.0"), 33, ipv4.ErrInvalidPrefix},
+		{"negative prefix", netip.MustParseAddr("10.0.0.0"), -1, ipv4.ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ipv4.FromAddr(tt.addr, tt.prefix)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("FromAddr() error = %v, want %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestFromAddr_Unmaps(t *testing.T) {
+	network, err := ipv4.FromAddr(netip.MustParseAddr("::ffff:10.0.0.1"), 8)
+	if err != nil {
+		t.Fatalf("FromAddr() error = %v", err)
+	}
+
+	if network.String() != "10.0.0.1/8" {
+		t.Errorf("String() = %q, want %q", network.String(), "10.0.0.1/8")
+	}
+}
+
+func TestIsValidCIDR(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want bool
+	}{
+		{"192.168.0.0/24", true},
+		{"10.1.2.3/8", true},
+		{"0.0.0.0/0", true},
+		{"10.0.0.0/255.0.0.0", true},
+		{"10.0.0.0/33", false},
+		{"300.0.0.0/8", false},
+		{"10.0.0.0", false},
+		{"10.0.0.0/", false},
+		{"2001:db8::/32", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ipv4.IsValidCIDR(tt.cidr); got != tt.want {
+			t.Errorf("IsValidCIDR(%q) = %v, want %v", tt.cidr, got, tt.want)
+		}
+
+		if _, err := ipv4.ParseCIDR(tt.cidr); (err == nil) != tt.want {
+			t.Errorf("ParseCIDR(%q) error = %v, disagrees with IsValidCIDR", tt.cidr, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package ipv4
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OctetValuesText returns a teaching table for the address: the place values of the bits in an
+// octet (128 down to 1), then every octet's bits lined up under them with the decimal value they
+// add up to, e.g. 1 1 0 0 0 0 0 0 = 192
+func (n *Network) OctetValuesText() string {
+	var b strings.Builder
+
+	b.WriteString("    Places:\t")
+
+	for bit := 7; bit >= 0; bit-- {
+		fmt.Fprintf(&b, "%4d", 1<<bit)
+	}
+
+	for i, octet := range n.Address.To4() {
+		fmt.Fprintf(&b, "\n   Octet %d:\t", i+1)
+
+		for bit := 7; bit >= 0; bit-- {
+			fmt.Fprintf(&b, "%4d", octet>>bit&1)
+		}
+
+		fmt.Fprintf(&b, " = %d", octet)
+	}
+
+	return b.String()
+}
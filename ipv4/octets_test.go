@@ -0,0 +1,25 @@
+package ipv4_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_OctetValuesText(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.1.10/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	expected := "" +
+		"    Places:\t 128  64  32  16   8   4   2   1\n" +
+		"   Octet 1:\t   1   1   0   0   0   0   0   0 = 192\n" +
+		"   Octet 2:\t   1   0   1   0   1   0   0   0 = 168\n" +
+		"   Octet 3:\t   0   0   0   0   0   0   0   1 = 1\n" +
+		"   Octet 4:\t   0   0   0   0   1   0   1   0 = 10"
+
+	if got := network.OctetValuesText(); got != expected {
+		t.Errorf("OctetValuesText() =\n%s\nwant\n%s", got, expected)
+	}
+}
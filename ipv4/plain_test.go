@@ -0,0 +1,49 @@
+package ipv4_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_PlainText(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.0.1/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output := network.PlainText()
+
+	if strings.Contains(output, "\t") {
+		t.Errorf("PlainText() contains tabs:\n%s", output)
+	}
+
+	if regexp.MustCompile(`[01]{8}`).MatchString(output) {
+		t.Errorf("PlainText() contains binary:\n%s", output)
+	}
+
+	expectedLines := []string{
+		"   Address: 192.168.0.1",
+		"  Wildcard: 0.0.0.255",
+		" Broadcast: 192.168.0.255",
+		"Host count: 254" + strings.Repeat(" ", 13) + "Class C, Private Internet",
+		"      Note: host bits set; network is 192.168.0.0/24",
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(output, line+"\n") && !strings.HasSuffix(output, line) {
+			t.Errorf("PlainText() missing line %q\n%s", line, output)
+		}
+	}
+
+	separator, longest := separatorAndLongestRow(output)
+	if separator != longest {
+		t.Errorf("separator length = %d, longest row = %d\n%s", separator, longest, output)
+	}
+}
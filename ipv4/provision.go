@@ -0,0 +1,85 @@
+package ipv4
+
+import "fmt"
+
+// maxProvisioningSubnets is the most subnets Split and ProvisioningTable return, e.g. a /16 split into /28s
+const maxProvisioningSubnets = 4096
+
+// Split returns the calculated /newPrefix subnets of the network in ascending order, with the
+// network's format options. It returns ErrInvalidPrefix if newPrefix is shorter than the network's
+// prefix, longer than /32, or would give more than 4096 subnets.
+func (n *Network) Split(newPrefix int) ([]*Network, error) {
+	if newPrefix < n.PrefixLength || newPrefix > 32 {
+		return nil, fmt.Errorf("%w: cannot split /%d into /%d", ErrInvalidPrefix, n.PrefixLength, newPrefix)
+	}
+
+	count := 1 << (newPrefix - n.PrefixLength)
+	if count > maxProvisioningSubnets {
+		return nil, fmt.Errorf("%w: splitting /%d into /%d gives %d subnets, more than %d",
+			ErrInvalidPrefix, n.PrefixLength, newPrefix, count, maxProvisioningSubnets)
+	}
+
+	first, _ := n.bounds()
+	size := uint32(1) << (32 - newPrefix)
+	subnets := make([]*Network, count)
+
+	for i := range subnets {
+		subnet := &Network{
+			Address:      fromUint32(first + uint32(i)*size),
+			PrefixLength: newPrefix,
+			Format:       n.Format,
+			CustomRanges: n.CustomRanges,
+		}
+
+		if err := subnet.Calculate(); err != nil {
+			return nil, fmt.Errorf("subnet.Calculate: %w", err)
+		}
+
+		subnets[i] = subnet
+	}
+
+	return subnets, nil
+}
+
+// ProvisioningRow is one subnet of a provisioning table with its conventional gateway (the first
+// usable address) and its broadcast address
+type ProvisioningRow struct {
+	Subnet    string
+	Gateway   string
+	Broadcast string
+}
+
+// ProvisioningTable splits the network into /newPrefix subnets and returns each with its gateway
+// and broadcast address, in ascending order, for pasting into DHCP and router templates. /31 and
+// /32 subnets use their first and last addresses, as they have no reserved ones. It returns
+// ErrInvalidPrefix if newPrefix is shorter than the network's prefix, longer than /32, or would
+// give more than 4096 subnets.
+func (n *Network) ProvisioningTable(newPrefix int) ([]ProvisioningRow, error) {
+	if newPrefix < n.PrefixLength || newPrefix > 32 {
+		return nil, fmt.Errorf("%w: cannot split /%d into /%d", ErrInvalidPrefix, n.PrefixLength, newPrefix)
+	}
+
+	count := 1 << (newPrefix - n.PrefixLength)
+	if count > maxProvisioningSubnets {
+		return nil, fmt.Errorf("%w: splitting /%d into /%d gives %d subnets, more than %d",
+			ErrInvalidPrefix, n.PrefixLength, newPrefix, count, maxProvisioningSubnets)
+	}
+
+	first, _ := n.bounds()
+	size := uint32(1) << (32 - newPrefix)
+	rows := make([]ProvisioningRow, count)
+
+	for i := range rows {
+		subnet := &Network{Address: fromUint32(first + uint32(i)*size), PrefixLength: newPrefix}
+		gateway, _ := subnet.usableBounds()
+		_, broadcast := subnet.bounds()
+
+		rows[i] = ProvisioningRow{
+			Subnet:    fmt.Sprintf("%s/%d", subnet.Address, newPrefix),
+			Gateway:   fromUint32(gateway).String(),
+			Broadcast: fromUint32(broadcast).String(),
+		}
+	}
+
+	return rows, nil
+}
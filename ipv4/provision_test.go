@@ -0,0 +1,134 @@
+package ipv4_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_ProvisioningTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		newPrefix int
+		want      []ipv4.ProvisioningRow
+	}{
+		{
+			name:      "/24 into /26",
+			cidr:      "10.0.0.0/24",
+			newPrefix: 26,
+			want: []ipv4.ProvisioningRow{
+				{Subnet: "10.0.0.0/26", Gateway: "10.0.0.1", Broadcast: "10.0.0.63"},
+				{Subnet: "10.0.0.64/26", Gateway: "10.0.0.65", Broadcast: "10.0.0.127"},
+				{Subnet: "10.0.0.128/26", Gateway: "10.0.0.129", Broadcast: "10.0.0.191"},
+				{Subnet: "10.0.0.192/26", Gateway: "10.0.0.193", Broadcast: "10.0.0.255"},
+			},
+		},
+		{
+			name:      "host bits ignored",
+			cidr:      "10.0.0.77/30",
+			newPrefix: 30,
+			want: []ipv4.ProvisioningRow{
+				{Subnet: "10.0.0.76/30", Gateway: "10.0.0.77", Broadcast: "10.0.0.79"},
+			},
+		},
+		{
+			name:      "point-to-point /31s",
+			cidr:      "10.0.0.0/30",
+			newPrefix: 31,
+			want: []ipv4.ProvisioningRow{
+				{Subnet: "10.0.0.0/31", Gateway: "10.0.0.0", Broadcast: "10.0.0.1"},
+				{Subnet: "10.0.0.2/31", Gateway: "10.0.0.2", Broadcast: "10.0.0.3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			got, err := network.ProvisioningTable(tt.newPrefix)
+			if err != nil {
+				t.Fatalf("ProvisioningTable() error = %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ProvisioningTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_ProvisioningTable_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		newPrefix int
+	}{
+		{"shorter prefix", "10.0.0.0/24", 23},
+		{"beyond /32", "10.0.0.0/24", 33},
+		{"too many subnets", "10.0.0.0/8", 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if _, err := network.ProvisioningTable(tt.newPrefix); !errors.Is(err, ipv4.ErrInvalidPrefix) {
+				t.Errorf("ProvisioningTable() error = %v, want %v", err, ipv4.ErrInvalidPrefix)
+			}
+
+			if _, err := network.Split(tt.newPrefix); !errors.Is(err, ipv4.ErrInvalidPrefix) {
+				t.Errorf("Split() error = %v, want %v", err, ipv4.ErrInvalidPrefix)
+			}
+		})
+	}
+}
+
+func TestNetwork_Split(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		newPrefix int
+		want      []string
+	}{
+		{"10.0.0.0/24", 26, []string{"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26"}},
+		{"10.0.0.77/24", 25, []string{"10.0.0.0/25", "10.0.0.128/25"}},
+		{"10.0.0.0/24", 24, []string{"10.0.0.0/24"}},
+		{"10.0.0.0/31", 32, []string{"10.0.0.0/32", "10.0.0.1/32"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			subnets, err := network.Split(tt.newPrefix)
+			if err != nil {
+				t.Fatalf("Split() error = %v", err)
+			}
+
+			got := make([]string, 0, len(subnets))
+			for _, subnet := range subnets {
+				got = append(got, subnet.String())
+
+				if subnet.Broadcast == nil {
+					t.Errorf("Split() returned %s uncalculated", subnet)
+				}
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package ipv4
+
+import (
+	"fmt"
+	"math/bits"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// RangeToCIDRs returns the minimal list of calculated networks exactly covering the inclusive
+// address range from start to end
+func RangeToCIDRs(start, end net.IP) ([]*Network, error) {
+	if start.To4() == nil || end.To4() == nil {
+		return nil, fmt.Errorf("%w: range bounds must be IPv4 addresses", ErrInvalidAddress)
+	}
+
+	first, last := uint64(toUint32(start)), uint64(toUint32(end))
+	if first > last {
+		return nil, fmt.Errorf("%w: %s is after %s", ErrInvalidRange, start, end)
+	}
+
+	var networks []*Network
+
+	for first <= last {
+		// The largest block starting at first is limited by its alignment and the end of the range
+		size := uint64(1) << 32
+		if first != 0 {
+			size = first & -first
+		}
+
+		for first+size-1 > last {
+			size >>= 1
+		}
+
+		network, err := newNetwork(uint32(first), 32-bits.TrailingZeros64(size))
+		if err != nil {
+			return nil, fmt.Errorf("newNetwork: %w", err)
+		}
+
+		networks = append(networks, network)
+		first += size
+	}
+
+	return networks, nil
+}
+
+// MaxAlignedPrefix returns the shortest prefix length for which ip is the network address, i.e. the
+// largest block it can start when aggregating: /7 for 10.0.0.0 (10 is even) and /23 for
+// 10.1.2.0. It returns 0 for 0.0.0.0 and -1 if ip isn't an IPv4 address.
+func MaxAlignedPrefix(ip net.IP) int {
+	if ip.To4() == nil {
+		return -1
+	}
+
+	return 32 - bits.TrailingZeros32(toUint32(ip))
+}
+
+// ParseDashRange parses an address range written with a dash, either as two full addresses
+// ("10.0.0.5-10.0.0.20") or with last-octet shorthand ("10.0.0.0-255"). It returns
+// ErrInvalidRange if the range is descending.
+func ParseDashRange(s string) (start, end net.IP, err error) {
+	from, to, found := strings.Cut(s, "-")
+	if !found {
+		return nil, nil, fmt.Errorf("%w: missing '-' in %q", ErrInvalidRange, s)
+	}
+
+	start = net.ParseIP(strings.TrimSpace(from)).To4()
+	if start == nil {
+		return nil, nil, fmt.Errorf("%w: invalid range start in %q", ErrInvalidAddress, s)
+	}
+
+	to = strings.TrimSpace(to)
+
+	if strings.Contains(to, ".") {
+		end = net.ParseIP(to).To4()
+		if end == nil {
+			return nil, nil, fmt.Errorf("%w: invalid range end in %q", ErrInvalidAddress, s)
+		}
+	} else {
+		lastOctet, parseErr := strconv.ParseUint(to, 10, 8)
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("%w: invalid last octet in %q", ErrInvalidAddress, s)
+		}
+
+		end = make(net.IP, 4)
+		copy(end, start)
+		end[3] = byte(lastOctet)
+	}
+
+	if toUint32(start) > toUint32(end) {
+		return nil, nil, fmt.Errorf("%w: %s is after %s", ErrInvalidRange, start, end)
+	}
+
+	return start, end, nil
+}
+
+// TileRange returns the aligned subnets of the given prefix length that fit entirely within the
+// inclusive address range from start to end, skipping any partial subnet at either edge
+func TileRange(start, end net.IP, prefix int) ([]*Network, error) {
+	return tileRange(start, end, prefix, false)
+}
+
+// TileRangeStrict is like TileRange but returns ErrInvalidRange instead of skipping a partial
+// subnet, i.e. when either edge of the range isn't on a subnet boundary
+func TileRangeStrict(start, end net.IP, prefix int) ([]*Network, error) {
+	return tileRange(start, end, prefix, true)
+}
+
+func tileRange(start, end net.IP, prefix int, strict bool) ([]*Network, error) {
+	first, last, size, err := subnetRange(start, end, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("subnetRange: %w", err)
+	}
+
+	// Round the start up and the end (exclusive) down to subnet boundaries
+	from := (first + size - 1) &^ (size - 1)
+	to := (last + 1) &^ (size - 1)
+
+	if strict && (from != first || to != last+1) {
+		return nil, fmt.Errorf("%w: %s-%s isn't aligned to /%d boundaries", ErrInvalidRange, start, end, prefix)
+	}
+
+	var networks []*Network
+
+	for base := from; base < to; base += size {
+		network, err := newNetwork(uint32(base), prefix)
+		if err != nil {
+			return nil, fmt.Errorf("newNetwork: %w", err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// BucketRange returns the distinct aligned subnets of the given prefix length that the inclusive
+// address range from start to end touches, e.g. both /24s for 10.0.0.200-10.0.1.10, to attribute
+// the range to buckets for accounting. Unlike TileRange, partial subnets at either edge are kept.
+func BucketRange(start, end net.IP, bucketPrefix int) ([]*Network, error) {
+	first, last, size, err := subnetRange(start, end, bucketPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("subnetRange: %w", err)
+	}
+
+	var networks []*Network
+
+	// Round both ends down to the start of the subnet they fall in
+	for base := first &^ (size - 1); base <= last; base += size {
+		network, err := newNetwork(uint32(base), bucketPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("newNetwork: %w", err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// subnetRange validates an inclusive address range and a subnet prefix length, returning the range
+// bounds and the subnet size as uint64s so arithmetic at the top of the address space can't wrap
+func subnetRange(start, end net.IP, prefix int) (first, last, size uint64, err error) {
+	if start.To4() == nil || end.To4() == nil {
+		return 0, 0, 0, fmt.Errorf("%w: range bounds must be IPv4 addresses", ErrInvalidAddress)
+	}
+
+	if prefix < 0 || prefix > 32 {
+		return 0, 0, 0, fmt.Errorf("%w: /%d is outside /0 to /32", ErrInvalidPrefix, prefix)
+	}
+
+	first, last = uint64(toUint32(start)), uint64(toUint32(end))
+	if first > last {
+		return 0, 0, 0, fmt.Errorf("%w: %s is after %s", ErrInvalidRange, start, end)
+	}
+
+	return first, last, uint64(1) << (32 - prefix), nil
+}
@@ -0,0 +1,368 @@
+package ipv4_test
+
+import (
+	"errors"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestParseDashRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantStart string
+		wantEnd   string
+		wantError error
+	}{
+		{
+			name:      "last-octet shorthand",
+			input:     "10.0.0.0-255",
+			wantStart: "10.0.0.0",
+			wantEnd:   "10.0.0.255",
+		},
+		{
+			name:      "full addresses",
+			input:     "10.0.0.5-10.0.0.20",
+			wantStart: "10.0.0.5",
+			wantEnd:   "10.0.0.20",
+		},
+		{
+			name:      "full addresses across octets",
+			input:     "10.0.0.200-10.0.1.10",
+			wantStart: "10.0.0.200",
+			wantEnd:   "10.0.1.10",
+		},
+		{
+			name:      "descending shorthand",
+			input:     "10.0.0.20-5",
+			wantError: ipv4.ErrInvalidRange,
+		},
+		{
+			name:      "descending full addresses",
+			input:     "10.0.1.0-10.0.0.255",
+			wantError: ipv4.ErrInvalidRange,
+		},
+		{
+			name:      "last octet out of range",
+			input:     "10.0.0.0-256",
+			wantError: ipv4.ErrInvalidAddress,
+		},
+		{
+			name:      "missing dash",
+			input:     "10.0.0.0",
+			wantError: ipv4.ErrInvalidRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := ipv4.ParseDashRange(tt.input)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("ParseDashRange() error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseDashRange() error = %v", err)
+			}
+
+			if start.String() != tt.wantStart {
+				t.Errorf("ParseDashRange() start = %v, want %v", start, tt.wantStart)
+			}
+
+			if end.String() != tt.wantEnd {
+				t.Errorf("ParseDashRange() end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestMaxAlignedPrefix(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want int
+	}{
+		// 10 is 00001010, so 10.0.0.0 is also the base of 10.0.0.0/7
+		{"10.0.0.0", 7},
+		{"10.1.2.0", 23},
+		{"192.168.1.1", 32},
+		{"172.16.0.0", 12},
+		{"0.0.0.0", 0},
+		{"2001:db8::", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			got := ipv4.MaxAlignedPrefix(net.ParseIP(tt.ip))
+			if got != tt.want {
+				t.Errorf("MaxAlignedPrefix(%s) = %d, want %d", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeToCIDRs(t *testing.T) {
+	tests := []struct {
+		name  string
+		start string
+		end   string
+		want  []string
+	}{
+		{
+			name:  "aligned /24",
+			start: "10.0.0.0",
+			end:   "10.0.0.255",
+			want:  []string{"10.0.0.0/24"},
+		},
+		{
+			name:  "unaligned range",
+			start: "10.0.0.5",
+			end:   "10.0.0.20",
+			want:  []string{"10.0.0.5/32", "10.0.0.6/31", "10.0.0.8/29", "10.0.0.16/30", "10.0.0.20/32"},
+		},
+		{
+			name:  "single address",
+			start: "192.168.1.1",
+			end:   "192.168.1.1",
+			want:  []string{"192.168.1.1/32"},
+		},
+		{
+			name:  "entire address space",
+			start: "0.0.0.0",
+			end:   "255.255.255.255",
+			want:  []string{"0.0.0.0/0"},
+		},
+		{
+			name:  "top of address space",
+			start: "255.255.255.254",
+			end:   "255.255.255.255",
+			want:  []string{"255.255.255.254/31"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			networks, err := ipv4.RangeToCIDRs(net.ParseIP(tt.start), net.ParseIP(tt.end))
+			if err != nil {
+				t.Fatalf("RangeToCIDRs() error = %v", err)
+			}
+
+			got := make([]string, 0, len(networks))
+			for _, network := range networks {
+				got = append(got, network.String())
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("RangeToCIDRs() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("RangeToCIDRs()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTileRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		prefix    int
+		strict    bool
+		want      []string
+		wantError error
+	}{
+		{
+			name:   "aligned /24s",
+			start:  "10.0.0.0",
+			end:    "10.0.3.255",
+			prefix: 24,
+			want:   []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		},
+		{
+			name:   "aligned /24s strict",
+			start:  "10.0.0.0",
+			end:    "10.0.3.255",
+			prefix: 24,
+			strict: true,
+			want:   []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		},
+		{
+			name:   "partial edges skipped",
+			start:  "10.0.0.7",
+			end:    "10.0.3.200",
+			prefix: 24,
+			want:   []string{"10.0.1.0/24", "10.0.2.0/24"},
+		},
+		{
+			name:   "too small for a subnet",
+			start:  "10.0.0.1",
+			end:    "10.0.0.254",
+			prefix: 24,
+			want:   []string{},
+		},
+		{
+			name:   "top of address space",
+			start:  "255.255.255.0",
+			end:    "255.255.255.255",
+			prefix: 25,
+			want:   []string{"255.255.255.0/25", "255.255.255.128/25"},
+		},
+		{
+			name:      "partial edges strict",
+			start:     "10.0.0.7",
+			end:       "10.0.3.255",
+			prefix:    24,
+			strict:    true,
+			wantError: ipv4.ErrInvalidRange,
+		},
+		{
+			name:      "descending",
+			start:     "10.0.3.255",
+			end:       "10.0.0.0",
+			prefix:    24,
+			wantError: ipv4.ErrInvalidRange,
+		},
+		{
+			name:      "invalid prefix",
+			start:     "10.0.0.0",
+			end:       "10.0.3.255",
+			prefix:    33,
+			wantError: ipv4.ErrInvalidPrefix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tile := ipv4.TileRange
+			if tt.strict {
+				tile = ipv4.TileRangeStrict
+			}
+
+			networks, err := tile(net.ParseIP(tt.start), net.ParseIP(tt.end), tt.prefix)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			got := make([]string, 0, len(networks))
+			for _, network := range networks {
+				got = append(got, network.String())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBucketRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		prefix    int
+		want      []string
+		wantError error
+	}{
+		{
+			name:   "spans two /24s",
+			start:  "10.0.0.200",
+			end:    "10.0.1.10",
+			prefix: 24,
+			want:   []string{"10.0.0.0/24", "10.0.1.0/24"},
+		},
+		{
+			name:   "inside one /24",
+			start:  "10.0.0.1",
+			end:    "10.0.0.254",
+			prefix: 24,
+			want:   []string{"10.0.0.0/24"},
+		},
+		{
+			name:   "single address",
+			start:  "10.0.5.9",
+			end:    "10.0.5.9",
+			prefix: 16,
+			want:   []string{"10.0.0.0/16"},
+		},
+		{
+			name:   "partial edges kept",
+			start:  "10.0.0.7",
+			end:    "10.0.3.200",
+			prefix: 24,
+			want:   []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"},
+		},
+		{
+			name:   "top of address space",
+			start:  "255.255.255.100",
+			end:    "255.255.255.255",
+			prefix: 25,
+			want:   []string{"255.255.255.0/25", "255.255.255.128/25"},
+		},
+		{
+			name:   "whole space at /0",
+			start:  "0.0.0.0",
+			end:    "255.255.255.255",
+			prefix: 0,
+			want:   []string{"0.0.0.0/0"},
+		},
+		{
+			name:      "descending",
+			start:     "10.0.1.0",
+			end:       "10.0.0.0",
+			prefix:    24,
+			wantError: ipv4.ErrInvalidRange,
+		},
+		{
+			name:      "invalid prefix",
+			start:     "10.0.0.0",
+			end:       "10.0.1.0",
+			prefix:    -1,
+			wantError: ipv4.ErrInvalidPrefix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			networks, err := ipv4.BucketRange(net.ParseIP(tt.start), net.ParseIP(tt.end), tt.prefix)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			got := make([]string, 0, len(networks))
+			for _, network := range networks {
+				got = append(got, network.String())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
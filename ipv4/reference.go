@@ -0,0 +1,55 @@
+package ipv4
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ReferenceTable returns a subnetting cheat-sheet covering every prefix length from /0 to /32 with
+// its netmask, wildcard, usable host count, and the number of such subnets within the enclosing
+// classful boundary (/8, /16 or /24)
+func ReferenceTable() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%-6s  %-15s  %-15s  %10s  %s\n", "Prefix", "Netmask", "Wildcard", "Hosts", "Subnets")
+
+	for prefixLen := 0; prefixLen <= 32; prefixLen++ {
+		netmask := net.IP(net.CIDRMask(prefixLen, 32))
+
+		fmt.Fprintf(&b, "%-6s  %-15s  %-15s  %10d  %s\n",
+			fmt.Sprintf("/%d", prefixLen),
+			netmask.String(),
+			invertMask(netmask).String(),
+			calculateHostCount(prefixLen),
+			classfulSubnets(prefixLen),
+		)
+	}
+
+	return b.String()
+}
+
+// classfulSubnets describes how many networks of the given prefix length fit in the closest
+// classful boundary at or above it, e.g. "4 per Class C" for a /26
+func classfulSubnets(prefixLen int) string {
+	switch {
+	case prefixLen >= 24:
+		return fmt.Sprintf("%d per Class C", 1<<(prefixLen-24))
+	case prefixLen >= 16:
+		return fmt.Sprintf("%d per Class B", 1<<(prefixLen-16))
+	case prefixLen >= 8:
+		return fmt.Sprintf("%d per Class A", 1<<(prefixLen-8))
+	default:
+		return "-"
+	}
+}
+
+// MagicNumber returns the interesting octet of the netmask (0-based, the octet holding the last
+// network bit) and the block size within it, 256 minus that mask octet. Subnets start at multiples
+// of the magic number in the interesting octet, e.g. a /26 has octet 3 and magic number 64.
+func (n *Network) MagicNumber() (octetIndex int, magic int) {
+	octetIndex = max(n.PrefixLength-1, 0) / 8
+	mask := net.CIDRMask(n.PrefixLength, 32)
+
+	return octetIndex, 256 - int(mask[octetIndex])
+}
@@ -0,0 +1,90 @@
+package ipv4_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestReferenceTable(t *testing.T) {
+	table := ipv4.ReferenceTable()
+
+	lines := strings.Split(strings.TrimSuffix(table, "\n"), "\n")
+	if len(lines) != 34 {
+		t.Fatalf("ReferenceTable() lines = %d, want 34 (header plus /0 to /32)", len(lines))
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{
+			prefix: "/8",
+			want:   []string{"255.0.0.0", "0.255.255.255", "16777214", "1 per Class A"},
+		},
+		{
+			prefix: "/26",
+			want:   []string{"255.255.255.192", "0.0.0.63", "62", "4 per Class C"},
+		},
+		{
+			prefix: "/32",
+			want:   []string{"255.255.255.255", "0.0.0.0", "256 per Class C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			row := findRow(lines, tt.prefix)
+			if row == "" {
+				t.Fatalf("ReferenceTable() missing row for %s", tt.prefix)
+			}
+
+			fields := strings.Join(strings.Fields(row), " ")
+			for _, want := range tt.want {
+				if !strings.Contains(fields, want) {
+					t.Errorf("ReferenceTable() row %q missing %q", row, want)
+				}
+			}
+		})
+	}
+}
+
+func findRow(lines []string, prefix string) string {
+	for _, line := range lines {
+		if strings.HasPrefix(line, prefix+" ") {
+			return line
+		}
+	}
+
+	return ""
+}
+
+func TestNetwork_MagicNumber(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		wantOctet int
+		wantMagic int
+	}{
+		{"10.0.0.0/26", 3, 64},
+		{"172.16.0.0/20", 2, 16},
+		{"192.168.1.0/24", 2, 1},
+		{"10.0.0.0/9", 1, 128},
+		{"10.0.0.0/32", 3, 1},
+		{"0.0.0.0/0", 0, 256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			octet, magic := network.MagicNumber()
+			if octet != tt.wantOctet || magic != tt.wantMagic {
+				t.Errorf("MagicNumber() = %d, %d, want %d, %d", octet, magic, tt.wantOctet, tt.wantMagic)
+			}
+		})
+	}
+}
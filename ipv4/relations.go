@@ -0,0 +1,123 @@
+package ipv4
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+)
+
+// IsProperSubnet reports whether child is a correctly carved subnet of n: a longer prefix,
+// aligned on its own boundary (no host bits set) and entirely inside n
+func (n *Network) IsProperSubnet(child *Network) bool {
+	if child.PrefixLength <= n.PrefixLength {
+		return false
+	}
+
+	first, last := n.bounds()
+	childFirst, childLast := child.bounds()
+
+	return childFirst == toUint32(child.Address) && childFirst >= first && childLast <= last
+}
+
+// IsAdjacentTo reports whether n and other are contiguous without overlapping, i.e. one starts at
+// the address just after the other's last, whatever their prefix lengths. Unlike siblings, adjacent
+// networks needn't be mergeable into a single prefix.
+func (n *Network) IsAdjacentTo(other *Network) bool {
+	first, last := n.bounds()
+	otherFirst, otherLast := other.bounds()
+
+	return uint64(last)+1 == uint64(otherFirst) || uint64(otherLast)+1 == uint64(first)
+}
+
+// Distance returns the signed number of addresses from a's base address to b's, negative when b
+// comes before a, to quantify the gap between allocations
+func Distance(a, b *Network) int64 {
+	aFirst, _ := a.bounds()
+	bFirst, _ := b.bounds()
+
+	return int64(bFirst) - int64(aFirst)
+}
+
+// Compare orders networks by base address and then by prefix length, shorter first so a supernet
+// sorts before its subnets, returning -1, 0 or +1 for use with slices.SortFunc
+func Compare(a, b *Network) int {
+	aFirst, _ := a.bounds()
+	bFirst, _ := b.bounds()
+
+	if c := cmp.Compare(aFirst, bFirst); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.PrefixLength, b.PrefixLength)
+}
+
+// Contains reports whether ip is one of the network's addresses
+func (n *Network) Contains(ip net.IP) bool {
+	if ip.To4() == nil {
+		return false
+	}
+
+	first, last := n.bounds()
+	addr := toUint32(ip)
+
+	return addr >= first && addr <= last
+}
+
+// CoveringPrefixes returns the 33 calculated networks containing ip, one for every prefix length
+// from /0 to /32, i.e. the chain of routes a routing table could match it against. It returns
+// ErrInvalidAddress if ip isn't an IPv4 address.
+func CoveringPrefixes(ip net.IP) ([]*Network, error) {
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("%w: %s is not an IPv4 address", ErrInvalidAddress, ip)
+	}
+
+	networks := make([]*Network, 0, 33)
+
+	for prefix := 0; prefix <= 32; prefix++ {
+		first, _ := (&Network{Address: ip.To4(), PrefixLength: prefix}).bounds()
+
+		network, err := newNetwork(first, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("newNetwork: %w", err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// IndexWithin returns the zero-based position of n among the same-size subnets of parent and how
+// many of them parent holds, e.g. 192.168.1.192/26 is index 3 of 4 within 192.168.1.0/24. It
+// returns ErrNotContained if n isn't inside parent.
+func (n *Network) IndexWithin(parent *Network) (index int, total int, err error) {
+	first, last := n.bounds()
+	parentFirst, parentLast := parent.bounds()
+
+	if n.PrefixLength < parent.PrefixLength || first < parentFirst || last > parentLast {
+		return 0, 0, fmt.Errorf("%w: %s is not inside %s", ErrNotContained, n, parent)
+	}
+
+	hostBits := 32 - n.PrefixLength
+
+	return int(uint64(first-parentFirst) >> hostBits), 1 << (n.PrefixLength - parent.PrefixLength), nil
+}
+
+// RollUp returns the supernet one bit shorter that n could be merged into and the sibling that
+// would complete it, e.g. 10.0.1.0/24 rolls up into 10.0.0.0/23 together with 10.0.0.0/24. The
+// networks are uncalculated, like those from ParseCIDR. Both are nil for a /0, which has no
+// supernet.
+func (n *Network) RollUp() (supernet *Network, sibling *Network) {
+	if n.PrefixLength == 0 {
+		return nil, nil
+	}
+
+	first, _ := n.bounds()
+	size := uint32(1) << (32 - n.PrefixLength)
+	parentFirst := first &^ size
+
+	supernet = &Network{Address: fromUint32(parentFirst), PrefixLength: n.PrefixLength - 1}
+	sibling = &Network{Address: fromUint32(first ^ size), PrefixLength: n.PrefixLength}
+
+	return supernet, sibling
+}
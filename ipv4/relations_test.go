@@ -0,0 +1,284 @@
+package ipv4_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_IsProperSubnet(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent string
+		child  string
+		want   bool
+	}{
+		{"aligned child", "10.0.0.0/24", "10.0.0.64/26", true},
+		{"aligned host route", "10.0.0.0/24", "10.0.0.255/32", true},
+		{"misaligned child", "10.0.0.0/24", "10.0.0.65/26", false},
+		{"outside parent", "10.0.0.0/24", "10.0.1.0/26", false},
+		{"same prefix", "10.0.0.0/24", "10.0.0.0/24", false},
+		{"shorter prefix", "10.0.0.0/24", "10.0.0.0/16", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := ipv4.ParseCIDR(tt.parent)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			child, err := ipv4.ParseCIDR(tt.child)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := parent.IsProperSubnet(child); got != tt.want {
+				t.Errorf("IsProperSubnet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_IsAdjacentTo(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{"following", "10.0.0.0/24", "10.0.1.0/24", true},
+		{"preceding", "10.0.1.0/24", "10.0.0.0/24", true},
+		{"different prefixes", "10.0.0.0/24", "10.0.1.0/26", true},
+		{"not mergeable", "10.0.1.0/24", "10.0.2.0/24", true},
+		{"gap", "10.0.0.0/24", "10.0.2.0/24", false},
+		{"overlapping", "10.0.0.0/23", "10.0.1.0/24", false},
+		{"same network", "10.0.0.0/24", "10.0.0.0/24", false},
+		{"top of the address space", "255.255.255.0/24", "0.0.0.0/24", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ipv4.ParseCIDR(tt.a)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			b, err := ipv4.ParseCIDR(tt.b)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := a.IsAdjacentTo(b); got != tt.want {
+				t.Errorf("IsAdjacentTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int64
+	}{
+		{"forward", "10.0.0.0/24", "10.0.4.0/24", 1024},
+		{"backward", "10.0.4.0/24", "10.0.0.0/24", -1024},
+		{"identical", "10.0.0.0/24", "10.0.0.0/24", 0},
+		{"host bits ignored", "10.0.0.77/24", "10.0.1.0/24", 256},
+		{"whole space", "0.0.0.0/32", "255.255.255.255/32", 4294967295},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ipv4.ParseCIDR(tt.a)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			b, err := ipv4.ParseCIDR(tt.b)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := ipv4.Distance(a, b); got != tt.want {
+				t.Errorf("Distance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"lower address first", "10.0.0.0/24", "10.0.1.0/24", -1},
+		{"higher address last", "192.168.0.0/16", "10.0.0.0/8", 1},
+		{"supernet before subnet", "10.0.0.0/16", "10.0.0.0/24", -1},
+		{"host bits ignored", "10.0.0.77/24", "10.0.0.0/24", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ipv4.ParseCIDR(tt.a)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			b, err := ipv4.ParseCIDR(tt.b)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := ipv4.Compare(a, b); got != tt.want {
+				t.Errorf("Compare() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_Contains(t *testing.T) {
+	tests := []struct {
+		cidr string
+		ip   string
+		want bool
+	}{
+		{"10.0.0.0/24", "10.0.0.5", true},
+		{"10.0.0.0/24", "10.0.0.0", true},
+		{"10.0.0.0/24", "10.0.0.255", true},
+		{"10.0.0.0/24", "10.0.1.0", false},
+		{"10.0.0.77/24", "10.0.0.1", true},
+		{"0.0.0.0/0", "255.255.255.255", true},
+		{"10.0.0.0/24", "2001:db8::1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr+" "+tt.ip, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.Contains(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_IndexWithin(t *testing.T) {
+	tests := []struct {
+		name      string
+		child     string
+		parent    string
+		wantIndex int
+		wantTotal int
+		wantError error
+	}{
+		{"last /26 of a /24", "192.168.1.192/26", "192.168.1.0/24", 3, 4, nil},
+		{"first /26 of a /24", "192.168.1.0/26", "192.168.1.0/24", 0, 4, nil},
+		{"host bits ignored", "192.168.1.70/26", "192.168.1.0/24", 1, 4, nil},
+		{"same prefix", "192.168.1.0/24", "192.168.1.0/24", 0, 1, nil},
+		{"host route in a /16", "10.1.2.3/32", "10.1.0.0/16", 515, 65536, nil},
+		{"whole address space", "255.0.0.0/8", "0.0.0.0/0", 255, 256, nil},
+		{"outside parent", "192.168.2.0/26", "192.168.1.0/24", 0, 0, ipv4.ErrNotContained},
+		{"larger than parent", "192.168.0.0/16", "192.168.1.0/24", 0, 0, ipv4.ErrNotContained},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child, err := ipv4.ParseCIDR(tt.child)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			parent, err := ipv4.ParseCIDR(tt.parent)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			index, total, err := child.IndexWithin(parent)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("IndexWithin() error = %v, want %v", err, tt.wantError)
+			}
+
+			if index != tt.wantIndex || total != tt.wantTotal {
+				t.Errorf("IndexWithin() = %d of %d, want %d of %d", index, total, tt.wantIndex, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestNetwork_RollUp(t *testing.T) {
+	tests := []struct {
+		cidr         string
+		wantSupernet string
+		wantSibling  string
+	}{
+		{"10.0.1.0/24", "10.0.0.0/23", "10.0.0.0/24"},
+		{"10.0.0.0/24", "10.0.0.0/23", "10.0.1.0/24"},
+		{"10.0.1.77/24", "10.0.0.0/23", "10.0.0.0/24"},
+		{"192.168.1.192/26", "192.168.1.128/25", "192.168.1.128/26"},
+		{"10.0.0.5/32", "10.0.0.4/31", "10.0.0.4/32"},
+		{"128.0.0.0/1", "0.0.0.0/0", "0.0.0.0/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			supernet, sibling := network.RollUp()
+			if supernet.String() != tt.wantSupernet || sibling.String() != tt.wantSibling {
+				t.Errorf("RollUp() = %s, %s, want %s, %s", supernet, sibling, tt.wantSupernet, tt.wantSibling)
+			}
+		})
+	}
+}
+
+func TestNetwork_RollUp_DefaultRoute(t *testing.T) {
+	network, err := ipv4.ParseCIDR("0.0.0.0/0")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if supernet, sibling := network.RollUp(); supernet != nil || sibling != nil {
+		t.Errorf("RollUp() = %v, %v, want nil, nil", supernet, sibling)
+	}
+}
+
+func TestCoveringPrefixes(t *testing.T) {
+	networks, err := ipv4.CoveringPrefixes(net.ParseIP("10.0.0.5"))
+	if err != nil {
+		t.Fatalf("CoveringPrefixes() error = %v", err)
+	}
+
+	if len(networks) != 33 {
+		t.Fatalf("CoveringPrefixes() returned %d networks, want 33", len(networks))
+	}
+
+	for prefix, want := range map[int]string{
+		0:  "0.0.0.0/0",
+		8:  "10.0.0.0/8",
+		16: "10.0.0.0/16",
+		24: "10.0.0.0/24",
+		30: "10.0.0.4/30",
+		32: "10.0.0.5/32",
+	} {
+		if got := networks[prefix].String(); got != want {
+			t.Errorf("CoveringPrefixes()[%d] = %s, want %s", prefix, got, want)
+		}
+	}
+
+	if _, err := ipv4.CoveringPrefixes(net.ParseIP("2001:db8::1")); !errors.Is(err, ipv4.ErrInvalidAddress) {
+		t.Errorf("CoveringPrefixes(2001:db8::1) error = %v, want ErrInvalidAddress", err)
+	}
+}
@@ -0,0 +1,50 @@
+package ipv4
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ronny/ripcalc/internal/zonefile"
+)
+
+// minZonePrefixLength is the shortest prefix ZoneFileTemplate renders, keeping the output to a
+// single in-addr.arpa zone of at most 256 addresses
+const minZonePrefixLength = 24
+
+// ZoneFileTemplate returns a BIND-style reverse zone file for the network with a placeholder SOA,
+// an NS record, and one PTR record per host mapping to host-N.domain, where N is the host's offset
+// from the network address. It returns an empty string for networks shorter than /24.
+func (n *Network) ZoneFileTemplate(domain string) string {
+	if n.PrefixLength < minZonePrefixLength {
+		return ""
+	}
+
+	domain = strings.TrimSuffix(domain, ".") + "."
+	network := n.Network.To4()
+
+	var b strings.Builder
+
+	b.WriteString(zonefile.Header(fmt.Sprintf("%d.%d.%d.in-addr.arpa.", network[2], network[1], network[0]), domain))
+
+	first, last := toUint32(n.Network), toUint32(n.Network)|^(^uint32(0)<<(32-n.PrefixLength))
+	if n.HostCount > 0 {
+		// Skip the network and broadcast addresses
+		first, last = first+1, last-1
+	}
+
+	for addr := first; ; addr++ {
+		fmt.Fprintf(&b, "%d\tIN\tPTR\thost-%d.%s\n", addr&0xff, addr-toUint32(n.Network), domain)
+
+		if addr == last {
+			break
+		}
+	}
+
+	return b.String()
+}
+
+// ForwardRecord returns a forward DNS A record for the address under name, the counterpart of the
+// PTR records in ZoneFileTemplate
+func (n *Network) ForwardRecord(name string) string {
+	return fmt.Sprintf("%s. IN A %s", strings.TrimSuffix(name, "."), n.Address)
+}
@@ -0,0 +1,86 @@
+package ipv4_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_ZoneFileTemplate(t *testing.T) {
+	network, err := ipv4.ParseCIDR("192.168.1.16/28")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	zone := network.ZoneFileTemplate("example.com")
+
+	expectedLines := []string{
+		"$ORIGIN 1.168.192.in-addr.arpa.",
+		"@\tIN\tNS\tns1.example.com.",
+		"17\tIN\tPTR\thost-1.example.com.",
+		"30\tIN\tPTR\thost-14.example.com.",
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(zone, line+"\n") {
+			t.Errorf("ZoneFileTemplate() missing line %q\n%s", line, zone)
+		}
+	}
+
+	if got := strings.Count(zone, "\tPTR\t"); got != 14 {
+		t.Errorf("ZoneFileTemplate() PTR records = %d, want 14", got)
+	}
+
+	// Network and broadcast addresses must not get PTR records
+	for _, owner := range []string{"16\t", "31\t"} {
+		if strings.Contains(zone, "\n"+owner) {
+			t.Errorf("ZoneFileTemplate() contains PTR for non-host address %q", owner)
+		}
+	}
+}
+
+func TestNetwork_ZoneFileTemplateTooLarge(t *testing.T) {
+	network, err := ipv4.ParseCIDR("10.0.0.0/16")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if zone := network.ZoneFileTemplate("example.com"); zone != "" {
+		t.Errorf("ZoneFileTemplate() = %q, want empty for /16", zone)
+	}
+}
+
+func TestNetwork_ForwardRecord(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		name     string
+		expected string
+	}{
+		{"192.168.0.1/24", "www.example.com", "www.example.com. IN A 192.168.0.1"},
+		{"10.0.0.5/32", "host.example.com.", "host.example.com. IN A 10.0.0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.ForwardRecord(tt.name); got != tt.expected {
+				t.Errorf("ForwardRecord() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
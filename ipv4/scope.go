@@ -0,0 +1,82 @@
+package ipv4
+
+import "net"
+
+// limitedBroadcast is the limited broadcast address (RFC 919), the one address of Class E in use
+var limitedBroadcast = net.IPv4(255, 255, 255, 255).To4()
+
+// multicastScopes maps multicast sub-ranges to their scope, checked in order so the MCAST-TEST-NET
+// carve-out is found before the GLOP block around it
+var multicastScopes = []struct {
+	network *net.IPNet
+	scope   string
+}{
+	{mustParseCIDR("224.0.0.0/24"), "Local"},       // Local Network Control Block (RFC 5771)
+	{mustParseCIDR("232.0.0.0/8"), "SSM"},          // Source-Specific Multicast (RFC 4607)
+	{mustParseCIDR("233.252.0.0/14"), "Global"},    // MCAST-TEST-NET (RFC 6676), not GLOP
+	{mustParseCIDR("233.0.0.0/8"), "GLOP"},         // AS-based static allocations (RFC 3180)
+	{mustParseCIDR("239.0.0.0/8"), "Admin-Scoped"}, // Administratively scoped (RFC 2365)
+	{mustParseCIDR("224.0.0.0/4"), "Global"},       // Everything else is routable between networks
+}
+
+// MulticastScope returns where a multicast group is meant to reach: "Local" for the link-local
+// control block 224.0.0.0/24, "SSM" for source-specific groups, "GLOP" for the AS-based 233/8,
+// "Admin-Scoped" for organisation-private 239/8 and "Global" for the rest. It returns an empty
+// string if ip isn't an IPv4 multicast address.
+func MulticastScope(ip net.IP) string {
+	ip = ip.To4()
+	if ip == nil {
+		return ""
+	}
+
+	for _, m := range multicastScopes {
+		if m.network.Contains(ip) {
+			return m.scope
+		}
+	}
+
+	return ""
+}
+
+// AddressScope returns a one-word bucket for where ip is meaningful: "Global" for public addresses,
+// "Private" (RFC 1918), "Shared" (RFC 6598 CGNAT space), "Link-Local" (RFC 3927), "Loopback",
+// "Multicast", "This-Network" for 0.0.0.0/8, "Broadcast" for 255.255.255.255, and "Reserved" for
+// documentation and the rest of Class E. It returns an empty string if ip isn't an IPv4 address.
+func AddressScope(ip net.IP) string {
+	ip = ip.To4()
+	if ip == nil {
+		return ""
+	}
+
+	if ip.Equal(limitedBroadcast) {
+		return "Broadcast"
+	}
+
+	if classifyAddress(ip) == "E" {
+		return "Reserved"
+	}
+
+	switch classifyAddressType(ip) {
+	case addressTypePrivate:
+		return "Private"
+	case addressTypeSharedAddressSpace:
+		return "Shared"
+	case addressTypeLinkLocal:
+		return "Link-Local"
+	case addressTypeLoopback:
+		return "Loopback"
+	case addressTypeMulticast:
+		return "Multicast"
+	case addressTypeThisNetwork:
+		return "This-Network"
+	case addressTypeDocumentation:
+		return "Reserved"
+	default:
+		return "Global"
+	}
+}
+
+// Scope returns the AddressScope of the network's address
+func (n *Network) Scope() string {
+	return AddressScope(n.Address)
+}
@@ -0,0 +1,120 @@
+package ipv4_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestAddressScope(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"8.8.8.8", "Global"},
+		{"10.1.2.3", "Private"},
+		{"172.16.0.1", "Private"},
+		{"192.168.1.1", "Private"},
+		{"100.64.0.1", "Shared"},
+		{"169.254.1.1", "Link-Local"},
+		{"127.0.0.1", "Loopback"},
+		{"224.0.0.1", "Multicast"},
+		{"0.1.2.3", "This-Network"},
+		{"255.255.255.255", "Broadcast"},
+		{"240.0.0.1", "Reserved"},
+		{"192.0.2.1", "Reserved"},
+		{"2001:db8::1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ipv4.AddressScope(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("AddressScope(%s) = %q, want %q", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_FormattedTextScopeRow(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		noClass bool
+		want    string
+	}{
+		{"100.64.1.0/24", false, "\n     Scope:\tShared"},
+		{"10.0.0.0/8", true, ""},
+		{"0.0.0.0/0", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv4.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.Format.NoClass = tt.noClass
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			for _, output := range []string{network.FormattedText(), network.FormattedTextNoBinary()} {
+				hasRow := strings.Contains(output, "Scope:")
+				if hasRow != (tt.want != "") || (tt.want != "" && !strings.Contains(output, tt.want)) {
+					t.Errorf("Scope row = %v, want %q\n%s", hasRow, tt.want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestMulticastScope(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"224.0.0.1", "Local"},
+		{"224.0.0.251", "Local"},
+		{"224.0.1.1", "Global"},
+		{"232.1.2.3", "SSM"},
+		{"233.1.2.3", "GLOP"},
+		{"233.252.0.1", "Global"},
+		{"239.1.1.1", "Admin-Scoped"},
+		{"239.255.255.250", "Admin-Scoped"},
+		{"10.0.0.1", ""},
+		{"240.0.0.1", ""},
+		{"ff02::1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ipv4.MulticastScope(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("MulticastScope(%s) = %q, want %q", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_ClassTypeMulticastScope(t *testing.T) {
+	for cidr, want := range map[string]string{
+		"224.0.0.1/32": "Class D, Multicast Local",
+		"239.1.1.1/32": "Class D, Multicast Admin-Scoped",
+		"10.0.0.0/8":   "Class A, Private Internet",
+	} {
+		network, err := ipv4.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatalf("ParseCIDR() error = %v", err)
+		}
+
+		if err := network.Calculate(); err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+
+		if got := network.ClassType(); got != want {
+			t.Errorf("ClassType() for %s = %q, want %q", cidr, got, want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package ipv4
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// SipcalcText returns the network in the layout of sipcalc's [CIDR] section, with the same field
+// labels and tab alignment, so scripts written against sipcalc can read ripcalc's output instead
+func (n *Network) SipcalcText() string {
+	first, last := n.bounds()
+	mask := net.IP(n.Netmask).To4()
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "-[ipv4 : %s/%d] - 0\n\n", n.Address, n.PrefixLength)
+	b.WriteString("[CIDR]\n")
+	fmt.Fprintf(&b, "Host address\t\t- %s\n", n.Address)
+	fmt.Fprintf(&b, "Host address (decimal)\t- %d\n", toUint32(n.Address))
+	fmt.Fprintf(&b, "Host address (hex)\t- %08X\n", toUint32(n.Address))
+	fmt.Fprintf(&b, "Network address\t\t- %s\n", fromUint32(first))
+	fmt.Fprintf(&b, "Network mask\t\t- %s\n", mask)
+	fmt.Fprintf(&b, "Network mask (bits)\t- %d\n", n.PrefixLength)
+	fmt.Fprintf(&b, "Network mask (hex)\t- %08X\n", toUint32(mask))
+	fmt.Fprintf(&b, "Broadcast address\t- %s\n", fromUint32(last))
+	fmt.Fprintf(&b, "Cisco wildcard\t\t- %s\n", n.Wildcard)
+	fmt.Fprintf(&b, "Addresses in network\t- %d\n", n.TotalAddresses())
+	fmt.Fprintf(&b, "Network range\t\t- %s - %s\n", fromUint32(first), fromUint32(last))
+	fmt.Fprintf(&b, "Usable range\t\t- %s - %s\n\n", n.FirstUsable(), n.LastUsable())
+	b.WriteString("-")
+
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package ipv4_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestNetwork_SipcalcText(t *testing.T) {
+	// Expected output of `sipcalc 192.168.1.10/24`
+	want := "-[ipv4 : 192.168.1.10/24] - 0\n" +
+		"\n" +
+		"[CIDR]\n" +
+		"Host address\t\t- 192.168.1.10\n" +
+		"Host address (decimal)\t- 3232235786\n" +
+		"Host address (hex)\t- C0A8010A\n" +
+		"Network address\t\t- 192.168.1.0\n" +
+		"Network mask\t\t- 255.255.255.0\n" +
+		"Network mask (bits)\t- 24\n" +
+		"Network mask (hex)\t- FFFFFF00\n" +
+		"Broadcast address\t- 192.168.1.255\n" +
+		"Cisco wildcard\t\t- 0.0.0.255\n" +
+		"Addresses in network\t- 256\n" +
+		"Network range\t\t- 192.168.1.0 - 192.168.1.255\n" +
+		"Usable range\t\t- 192.168.1.1 - 192.168.1.254\n" +
+		"\n" +
+		"-"
+
+	network, err := ipv4.ParseCIDR("192.168.1.10/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if got := network.SipcalcText(); got != want {
+		t.Errorf("SipcalcText() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package ipv4
+
+import "net"
+
+// IsDocumentation reports whether ip is in one of the ranges reserved for documentation and
+// examples (RFC 5737): TEST-NET-1 192.0.2.0/24, TEST-NET-2 198.51.100.0/24 and TEST-NET-3
+// 203.0.113.0/24
+func IsDocumentation(ip net.IP) bool {
+	return ip.To4() != nil && classifyAddressType(ip.To4()) == addressTypeDocumentation
+}
+
+// IsGloballyRoutable reports whether ip would be routed on the public internet, i.e. it's outside
+// every special-purpose range: this network, private, shared address space (CGNAT), link-local,
+// loopback, multicast, documentation, and the reserved Class E space including the limited
+// broadcast address
+func IsGloballyRoutable(ip net.IP) bool {
+	ip = ip.To4()
+	if ip == nil || classifyAddress(ip) == "E" {
+		return false
+	}
+
+	return classifyAddressType(ip) == addressTypePublic
+}
@@ -0,0 +1,64 @@
+package ipv4_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+func TestIsDocumentation(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"192.0.2.0", true},
+		{"192.0.2.255", true},
+		{"198.51.100.7", true},
+		{"203.0.113.200", true},
+		{"192.0.3.1", false},
+		{"198.51.101.1", false},
+		{"203.0.112.255", false},
+		{"8.8.8.8", false},
+		{"2001:db8::1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ipv4.IsDocumentation(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsDocumentation(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsGloballyRoutable(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"1.1.1.1", true},
+		{"172.32.0.1", true},
+		{"0.1.2.3", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"100.64.0.1", false},
+		{"169.254.1.1", false},
+		{"127.0.0.1", false},
+		{"224.0.0.251", false},
+		{"198.51.100.7", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"2001:4860::8888", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ipv4.IsGloballyRoutable(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsGloballyRoutable(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package ipv6
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// span is an inclusive range of addresses as integers
+type span struct {
+	first, last *big.Int
+}
+
+// Aggregate returns the minimal list of calculated networks covering exactly the same addresses as
+// nets, the IPv6 counterpart of ipv4.Aggregate. Overlapping, nested and adjacent networks are
+// merged and the result is sorted by address.
+func Aggregate(nets []*Network) ([]*Network, error) {
+	spans := make([]span, 0, len(nets))
+
+	for _, n := range nets {
+		first, last := n.bounds()
+		spans = append(spans, span{first, last})
+	}
+
+	// Sorting by base then by prefix length (widest first) puts covering networks before the
+	// networks they contain
+	slices.SortFunc(spans, func(a, b span) int {
+		if c := a.first.Cmp(b.first); c != 0 {
+			return c
+		}
+
+		return b.last.Cmp(a.last)
+	})
+
+	// merged works as a stack whose top is the run currently being extended
+	var merged []span
+
+	one := big.NewInt(1)
+
+	for _, s := range spans {
+		if top := len(merged) - 1; top >= 0 && s.first.Cmp(new(big.Int).Add(merged[top].last, one)) <= 0 {
+			if s.last.Cmp(merged[top].last) > 0 {
+				merged[top].last = s.last
+			}
+
+			continue
+		}
+
+		merged = append(merged, s)
+	}
+
+	var networks []*Network
+
+	for _, s := range merged {
+		covering, err := spanToCIDRs(s)
+		if err != nil {
+			return nil, fmt.Errorf("spanToCIDRs: %w", err)
+		}
+
+		networks = append(networks, covering...)
+	}
+
+	return networks, nil
+}
+
+// spanToCIDRs returns the minimal list of calculated networks covering exactly the addresses of s,
+// taking the largest aligned block that fits at each step
+func spanToCIDRs(s span) ([]*Network, error) {
+	var networks []*Network
+
+	first := new(big.Int).Set(s.first)
+
+	for first.Cmp(s.last) <= 0 {
+		// The largest block starting at first is limited by its alignment and the end of the range
+		hostBits := 128
+		if first.Sign() != 0 {
+			hostBits = int(first.TrailingZeroBits())
+		}
+
+		size := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+		for new(big.Int).Sub(new(big.Int).Add(first, size), big.NewInt(1)).Cmp(s.last) > 0 {
+			size.Rsh(size, 1)
+			hostBits--
+		}
+
+		network := &Network{Address: fromBigInt(first), PrefixLength: 128 - hostBits}
+		if err := network.Calculate(); err != nil {
+			return nil, fmt.Errorf("network.Calculate: %w", err)
+		}
+
+		networks = append(networks, network)
+		first.Add(first, size)
+	}
+
+	return networks, nil
+}
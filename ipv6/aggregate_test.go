@@ -0,0 +1,81 @@
+package ipv6_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  string
+	}{
+		{
+			name:  "adjacent halves",
+			input: []string{"2001:db8:8000::/33", "2001:db8::/33"},
+			want:  "2001:db8::/32",
+		},
+		{
+			name:  "nested",
+			input: []string{"2001:db8:1::/48", "2001:db8::/32", "2001:db8::1/128"},
+			want:  "2001:db8::/32",
+		},
+		{
+			name:  "adjacent but unaligned",
+			input: []string{"2001:db8:1::/48", "2001:db8:2::/48"},
+			want:  "2001:db8:1::/48 2001:db8:2::/48",
+		},
+		{
+			name:  "disjoint",
+			input: []string{"fd00::/8", "2001:db8::/32"},
+			want:  "2001:db8::/32 fd00::/8",
+		},
+		{
+			name:  "host bits ignored",
+			input: []string{"2001:db8::1/127", "2001:db8::3/127"},
+			want:  "2001:db8::/126",
+		},
+		{
+			name:  "whole space",
+			input: []string{"8000::/1", "::/1", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"},
+			want:  "::/0",
+		},
+		{
+			name:  "empty",
+			input: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			networks := make([]*ipv6.Network, 0, len(tt.input))
+
+			for _, cidr := range tt.input {
+				network, err := ipv6.ParseCIDR(cidr)
+				if err != nil {
+					t.Fatalf("ParseCIDR(%q) error = %v", cidr, err)
+				}
+
+				networks = append(networks, network)
+			}
+
+			got, err := ipv6.Aggregate(networks)
+			if err != nil {
+				t.Fatalf("Aggregate() error = %v", err)
+			}
+
+			cidrs := make([]string, 0, len(got))
+			for _, network := range got {
+				cidrs = append(cidrs, network.CanonicalCIDR())
+			}
+
+			if s := strings.Join(cidrs, " "); s != tt.want {
+				t.Errorf("Aggregate() = %q, want %q", s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package ipv6
+
+import "net"
+
+// CustomRange labels an operator-defined range, such as a corporate DMZ, for classification
+type CustomRange struct {
+	Network *net.IPNet
+	Label   string
+}
+
+// customLabel returns the label of the first custom range containing the address
+func (n *Network) customLabel() (string, bool) {
+	for _, r := range n.CustomRanges {
+		if r.Network.Contains(n.Address) {
+			return r.Label, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,44 @@
+package ipv6_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestCalculate_CustomRanges(t *testing.T) {
+	_, dmz, err := net.ParseCIDR("2001:db8:ca7::/48")
+	if err != nil {
+		t.Fatalf("net.ParseCIDR() error = %v", err)
+	}
+
+	ranges := []ipv6.CustomRange{{Network: dmz, Label: "Corp DMZ"}}
+
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{"2001:db8:ca7:1::/64", "Corp DMZ"},
+		{"2001:db8:1::/64", "RFC Example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.CustomRanges = ranges
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if network.Type != tt.want {
+				t.Errorf("Type = %q, want %q", network.Type, tt.want)
+			}
+		})
+	}
+}
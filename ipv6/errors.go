@@ -4,4 +4,6 @@ import "errors"
 
 var (
 	ErrInvalidAddress = errors.New("invalid address")
+	ErrInvalidPrefix  = errors.New("invalid prefix length")
+	ErrHostOutOfRange = errors.New("host out of range")
 )
@@ -0,0 +1,31 @@
+package ipv6
+
+import (
+	"fmt"
+	"net"
+)
+
+// eui64PrefixLength is the only prefix length SLAAC forms interface identifiers for
+const eui64PrefixLength = 64
+
+// AddressFromMAC returns the address SLAAC would autoconfigure in the network for a 48-bit MAC
+// address, using the modified EUI-64 interface identifier (RFC 4291 appendix A): ff:fe is inserted
+// in the middle of the MAC and the universal/local bit is flipped. It returns ErrInvalidPrefix
+// unless the network is a /64, and ErrInvalidAddress for a MAC that isn't 48 bits.
+func (n *Network) AddressFromMAC(mac net.HardwareAddr) (net.IP, error) {
+	if !n.SupportsSLAAC() {
+		return nil, fmt.Errorf("%w: EUI-64 addresses need a /64, not /%d", ErrInvalidPrefix, n.PrefixLength)
+	}
+
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("%w: MAC %s isn't 48 bits", ErrInvalidAddress, mac)
+	}
+
+	ip := n.Address.To16().Mask(net.CIDRMask(eui64PrefixLength, 128))
+	ip[8] = mac[0] ^ 0x02
+	copy(ip[9:11], mac[1:3])
+	ip[11], ip[12] = 0xff, 0xfe
+	copy(ip[13:16], mac[3:6])
+
+	return ip, nil
+}
@@ -0,0 +1,55 @@
+package ipv6_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestNetwork_AddressFromMAC(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		mac       string
+		want      string
+		wantError error
+	}{
+		{"fe80::/64", "00:11:22:33:44:55", "fe80::211:22ff:fe33:4455", nil},
+		{"2001:db8:1:2::/64", "52:54:00:ab:cd:ef", "2001:db8:1:2:5054:ff:feab:cdef", nil},
+		{"2001:db8::1234/64", "02:00:00:00:00:01", "2001:db8::ff:fe00:1", nil},
+		{"2001:db8::/48", "00:11:22:33:44:55", "", ipv6.ErrInvalidPrefix},
+		{"fe80::/64", "02:00:5e:10:00:00:00:01", "", ipv6.ErrInvalidAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr+" "+tt.mac, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			mac, err := net.ParseMAC(tt.mac)
+			if err != nil {
+				t.Fatalf("net.ParseMAC() error = %v", err)
+			}
+
+			got, err := network.AddressFromMAC(mac)
+			if tt.wantError != nil {
+				if !errors.Is(err, tt.wantError) {
+					t.Errorf("AddressFromMAC() error = %v, want %v", err, tt.wantError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("AddressFromMAC() error = %v", err)
+			}
+
+			if got.String() != tt.want {
+				t.Errorf("AddressFromMAC() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package ipv6_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		// First 8 hex digits of sha256("2001:db8::/32")
+		{"2001:db8::/32", "90c2cbc2"},
+		{"2001:db8:ffff::1/32", "90c2cbc2"},
+		{"2001:0db8:0000::/32", "90c2cbc2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.Fingerprint(); got != tt.want {
+				t.Errorf("Fingerprint() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package ipv6
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HextetValuesText returns a teaching table for the address: the place values 8 4 2 1 of the bits
+// in each nibble, then every hextet's bits lined up under them with the hex digits they spell and
+// the decimal value, e.g. 0010 0000 0000 0001 = 0x2001 = 8193
+func (n *Network) HextetValuesText() string {
+	var b strings.Builder
+
+	b.WriteString("    Places:\t" + strings.TrimSpace(strings.Repeat("8421 ", 4)))
+
+	ip := n.Address.To16()
+	for i := 0; i < 16; i += 2 {
+		hextet := uint16(ip[i])<<8 | uint16(ip[i+1])
+		binary := fmt.Sprintf("%016b", hextet)
+
+		fmt.Fprintf(&b, "\n  Hextet %d:\t%s %s %s %s = %#04x = %d", i/2+1,
+			binary[0:4], binary[4:8], binary[8:12], binary[12:16], hextet, hextet)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package ipv6_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestNetwork_HextetValuesText(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::1/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	lines := strings.Split(network.HextetValuesText(), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("HextetValuesText() has %d lines, want 9:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+
+	for i, want := range map[int]string{
+		0: "    Places:\t8421 8421 8421 8421",
+		1: "  Hextet 1:\t0010 0000 0000 0001 = 0x2001 = 8193",
+		2: "  Hextet 2:\t0000 1101 1011 1000 = 0x0db8 = 3512",
+		8: "  Hextet 8:\t0000 0000 0000 0001 = 0x0001 = 1",
+	} {
+		if lines[i] != want {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package ipv6
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// WithHost returns the address formed by placing host into the host portion of the network. It
+// returns ErrInvalidPrefix if the prefix length is outside /0 to /128, and ErrHostOutOfRange if host
+// is negative or doesn't fit in the available host bits.
+func (n *Network) WithHost(host *big.Int) (net.IP, error) {
+	if n.PrefixLength < 0 || n.PrefixLength > 128 {
+		return nil, fmt.Errorf("%w: /%d is outside /0 to /128", ErrInvalidPrefix, n.PrefixLength)
+	}
+
+	hostBits := 128 - n.PrefixLength
+	if host.Sign() < 0 || host.BitLen() > hostBits {
+		return nil, fmt.Errorf("%w: %s needs more than %d host bits", ErrHostOutOfRange, host, hostBits)
+	}
+
+	network := toBigInt(n.Address.Mask(net.CIDRMask(n.PrefixLength, 128)))
+
+	return fromBigInt(network.Or(network, host)), nil
+}
+
+// FirstUsable returns the first address that can be assigned to a host, skipping the
+// Subnet-Router anycast address (RFC 4291) at the start of the network. Point-to-point /127
+// networks (RFC 6164) and /128 host routes have no reserved addresses.
+func (n *Network) FirstUsable() net.IP {
+	if n.PrefixLength >= 127 {
+		return n.HostMin
+	}
+
+	return fromBigInt(new(big.Int).Add(toBigInt(n.Network), big.NewInt(1)))
+}
+
+// LastUsable returns the last address that can be assigned to a host
+func (n *Network) LastUsable() net.IP {
+	return n.HostMax
+}
+
+// TotalAddresses returns the number of addresses in the network, 2^(128-prefix)
+func (n *Network) TotalAddresses() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(128-n.PrefixLength))
+}
+
+// BitCounts returns how the 128 address bits split into network bits (the prefix length) and
+// host bits, e.g. 64 and 64 for a /64
+func (n *Network) BitCounts() (networkBits, hostBits int) {
+	return n.PrefixLength, 128 - n.PrefixLength
+}
+
+// Preview returns the first count and last count addresses of the network, counting the
+// Subnet-Router anycast address, for a feel of the space without listing it. When the network
+// holds no more than 2*count addresses, first has all of them and last is empty so none repeat.
+func (n *Network) Preview(count int) (first, last []net.IP) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	start, end := n.bounds()
+	total := n.TotalAddresses()
+
+	limit := big.NewInt(int64(count))
+	if total.Cmp(new(big.Int).Lsh(limit, 1)) <= 0 {
+		limit = total
+	}
+
+	for i := new(big.Int); i.Cmp(limit) < 0; i.Add(i, big.NewInt(1)) {
+		first = append(first, fromBigInt(new(big.Int).Add(start, i)))
+	}
+
+	if limit == total {
+		return first, nil
+	}
+
+	for i := new(big.Int).Sub(limit, big.NewInt(1)); i.Sign() >= 0; i.Sub(i, big.NewInt(1)) {
+		last = append(last, fromBigInt(new(big.Int).Sub(end, i)))
+	}
+
+	return first, last
+}
@@ -0,0 +1,243 @@
+package ipv6_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestWithHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidr     string
+		host     *big.Int
+		expected string
+		wantErr  error
+		// prefix replaces the parsed prefix length when set, for lengths ParseCIDR rejects
+		prefix *int
+	}{
+		{
+			name:     "first host of /64",
+			cidr:     "2001:db8::/64",
+			host:     big.NewInt(1),
+			expected: "2001:db8::1",
+		},
+		{
+			name:     "host bits in input are replaced",
+			cidr:     "2001:db8::abcd/112",
+			host:     big.NewInt(0x1234),
+			expected: "2001:db8::1234",
+		},
+		{
+			name:     "full interface identifier",
+			cidr:     "2001:db8::/64",
+			host:     new(big.Int).SetUint64(0x0211_22ff_fe33_4455),
+			expected: "2001:db8::211:22ff:fe33:4455",
+		},
+		{
+			name:    "host exceeds /120",
+			cidr:    "2001:db8::/120",
+			host:    big.NewInt(256),
+			wantErr: ipv6.ErrHostOutOfRange,
+		},
+		{
+			name:    "negative host",
+			cidr:    "2001:db8::/64",
+			host:    big.NewInt(-1),
+			wantErr: ipv6.ErrHostOutOfRange,
+		},
+		{
+			name:    "negative prefix length",
+			cidr:    "2001:db8::/64",
+			host:    new(big.Int).Lsh(big.NewInt(1), 130),
+			wantErr: ipv6.ErrInvalidPrefix,
+			prefix:  intPtr(-5),
+		},
+		{
+			name:    "prefix length beyond /128",
+			cidr:    "2001:db8::/64",
+			host:    big.NewInt(0),
+			wantErr: ipv6.ErrInvalidPrefix,
+			prefix:  intPtr(129),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() unexpected error: %v", err)
+			}
+
+			if tt.prefix != nil {
+				network.PrefixLength = *tt.prefix
+			}
+
+			result, err := network.WithHost(tt.host)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("WithHost() error = %v, expected %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("WithHost() unexpected error: %v", err)
+			}
+
+			if result.String() != tt.expected {
+				t.Errorf("WithHost() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// intPtr returns a pointer to v, for optional table fields
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestFirstAndLastUsable(t *testing.T) {
+	tests := []struct {
+		name          string
+		cidr          string
+		expectedFirst string
+		expectedLast  string
+	}{
+		{
+			name:          "/64 skips Subnet-Router anycast",
+			cidr:          "2001:db8::/64",
+			expectedFirst: "2001:db8::1",
+			expectedLast:  "2001:db8::ffff:ffff:ffff:ffff",
+		},
+		{
+			name:          "/127 uses both addresses",
+			cidr:          "2001:db8::/127",
+			expectedFirst: "2001:db8::",
+			expectedLast:  "2001:db8::1",
+		},
+		{
+			name:          "/128 is the single host",
+			cidr:          "2001:db8::5/128",
+			expectedFirst: "2001:db8::5",
+			expectedLast:  "2001:db8::5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() unexpected error: %v", err)
+			}
+
+			err = network.Calculate()
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+
+			if result := network.FirstUsable().String(); result != tt.expectedFirst {
+				t.Errorf("FirstUsable() = %v, expected %v", result, tt.expectedFirst)
+			}
+
+			if result := network.LastUsable().String(); result != tt.expectedLast {
+				t.Errorf("LastUsable() = %v, expected %v", result, tt.expectedLast)
+			}
+
+			if !network.LastUsable().Equal(network.HostMax) {
+				t.Errorf("LastUsable() = %v, expected HostMax %v", network.LastUsable(), network.HostMax)
+			}
+		})
+	}
+}
+
+func TestTotalAddresses(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{"2001:db8::/64", "18446744073709551616"},
+		{"2001:db8::1/128", "1"},
+		{"::/0", "340282366920938463463374607431768211456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.TotalAddresses(); got.String() != tt.want {
+				t.Errorf("TotalAddresses() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		count     int
+		wantFirst string
+		wantLast  string
+	}{
+		{
+			cidr:      "2001:db8::/64",
+			count:     3,
+			wantFirst: "2001:db8:: 2001:db8::1 2001:db8::2",
+			wantLast:  "2001:db8::ffff:ffff:ffff:fffd 2001:db8::ffff:ffff:ffff:fffe 2001:db8::ffff:ffff:ffff:ffff",
+		},
+		{
+			cidr:      "2001:db8::1/126",
+			count:     2,
+			wantFirst: "2001:db8:: 2001:db8::1 2001:db8::2 2001:db8::3",
+			wantLast:  "",
+		},
+		{
+			cidr:      "2001:db8::/127",
+			count:     3,
+			wantFirst: "2001:db8:: 2001:db8::1",
+			wantLast:  "",
+		},
+		{
+			cidr:      "2001:db8::/64",
+			count:     0,
+			wantFirst: "",
+			wantLast:  "",
+		},
+	}
+
+	join := func(ips []net.IP) string {
+		s := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			s = append(s, ip.String())
+		}
+
+		return strings.Join(s, " ")
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s first %d", tt.cidr, tt.count), func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			first, last := network.Preview(tt.count)
+			if got := join(first); got != tt.wantFirst {
+				t.Errorf("Preview() first = %q, want %q", got, tt.wantFirst)
+			}
+
+			if got := join(last); got != tt.wantLast {
+				t.Errorf("Preview() last = %q, want %q", got, tt.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package ipv6
+
+import "fmt"
+
+// OSPFStatement returns the OSPFv3 statement naming the network in area, e.g.
+// "area 0 range 2001:db8::/64". OSPFv3 is enabled per interface rather than by matching networks
+// with a wildcard mask, so the prefix appears in the area range that summarises it instead.
+// Calculate must have been called.
+func (n *Network) OSPFStatement(area int) string {
+	return fmt.Sprintf("area %d range %s/%d", area, n.formatAddress(n.Network), n.PrefixLength)
+}
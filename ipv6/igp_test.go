@@ -0,0 +1,22 @@
+package ipv6_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestNetwork_OSPFStatement(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::1/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if got, want := network.OSPFStatement(0), "area 0 range 2001:db8::/64"; got != want {
+		t.Errorf("OSPFStatement() = %q, want %q", got, want)
+	}
+}
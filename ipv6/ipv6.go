@@ -5,6 +5,9 @@ import (
 	"math/big"
 	"net"
 	"strings"
+
+	"github.com/ronny/ripcalc/internal/layout"
+	"github.com/ronny/ripcalc/internal/trie"
 )
 
 type addressType int
@@ -21,6 +24,10 @@ const (
 	addressTypeTeredo
 	addressTypeIPv4Mapped
 	addressTypeReserved
+	addressTypeDiscard
+	addressTypeBenchmarking
+	addressTypeORCHID
+	addressTypeDefaultRoute
 )
 
 func (at addressType) String() string {
@@ -36,7 +43,7 @@ func (at addressType) String() string {
 	case addressTypeLoopback:
 		return "Host-only"
 	case addressTypeUnspecified:
-		return "Default/Undefined"
+		return "Unconfigured Source"
 	case addressTypeDocumentation:
 		return "RFC Example"
 	case addressType6to4:
@@ -47,11 +54,57 @@ func (at addressType) String() string {
 		return "Embedded IPv4"
 	case addressTypeReserved:
 		return "Reserved"
+	case addressTypeDiscard:
+		return "Discard Only"
+	case addressTypeBenchmarking:
+		return "Benchmark Testing"
+	case addressTypeORCHID:
+		return "Cryptographic Identifier"
+	case addressTypeDefaultRoute:
+		return "Default Route"
 	default:
 		return "Unknown"
 	}
 }
 
+// jsonName returns the identifier used for the address type in JSON output
+func (at addressType) jsonName() string {
+	switch at {
+	case addressTypeGlobalUnicast:
+		return "GLOBAL_UNICAST"
+	case addressTypeLinkLocal:
+		return "LINK_LOCAL"
+	case addressTypeUniqueLocal:
+		return "UNIQUE_LOCAL"
+	case addressTypeMulticast:
+		return "MULTICAST"
+	case addressTypeLoopback:
+		return "LOOPBACK"
+	case addressTypeUnspecified:
+		return "UNSPECIFIED"
+	case addressTypeDocumentation:
+		return "DOCUMENTATION"
+	case addressType6to4:
+		return "6TO4"
+	case addressTypeTeredo:
+		return "TEREDO"
+	case addressTypeIPv4Mapped:
+		return "IPV4_MAPPED"
+	case addressTypeReserved:
+		return "RESERVED"
+	case addressTypeDiscard:
+		return "DISCARD"
+	case addressTypeBenchmarking:
+		return "BENCHMARKING"
+	case addressTypeORCHID:
+		return "ORCHID"
+	case addressTypeDefaultRoute:
+		return "DEFAULT_ROUTE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 type addressRange struct {
 	network *net.IPNet
 	typ     addressType
@@ -65,12 +118,58 @@ var specialRanges = []addressRange{
 	{mustParseCIDR("fc00::/7"), addressTypeUniqueLocal, "Unique Local Address"},
 	{mustParseCIDR("ff00::/8"), addressTypeMulticast, "Multicast"},
 	{mustParseCIDR("2001:db8::/32"), addressTypeDocumentation, "Documentation"},
+	{mustParseCIDR("3fff::/20"), addressTypeDocumentation, "Documentation"},
+	{mustParseCIDR("100::/64"), addressTypeDiscard, "Discard"},
+	{mustParseCIDR("2001:2::/48"), addressTypeBenchmarking, "Benchmarking"},
+	{mustParseCIDR("2001:10::/28"), addressTypeORCHID, "ORCHID (Deprecated)"},
+	{mustParseCIDR("2001:20::/28"), addressTypeORCHID, "ORCHIDv2"},
 	{mustParseCIDR("2002::/16"), addressType6to4, "6to4"},
 	{mustParseCIDR("2001::/32"), addressTypeTeredo, "Teredo"},
 	{mustParseCIDR("::ffff:0:0/96"), addressTypeIPv4Mapped, "IPv4-Mapped"},
 	{mustParseCIDR("2000::/3"), addressTypeGlobalUnicast, "Global Unicast"},
 }
 
+// rirRange is a major global unicast allocation and the regional registry responsible for it
+type rirRange struct {
+	network *net.IPNet
+	rir     string
+}
+
+// rirRanges refine the Global Unicast classification when the RIR format option is set
+var rirRanges = []rirRange{
+	{mustParseCIDR("2001::/16"), "IANA"},
+	{mustParseCIDR("2400::/12"), "APNIC"},
+	{mustParseCIDR("2600::/12"), "ARIN"},
+	{mustParseCIDR("2800::/12"), "LACNIC"},
+	{mustParseCIDR("2a00::/12"), "RIPE NCC"},
+	{mustParseCIDR("2c00::/12"), "AFRINIC"},
+}
+
+// specialRangeTrie and rirRangeTrie index specialRanges and rirRanges for longest-prefix-match
+// lookups, so the most specific containing range wins whatever its position in the table.
+var (
+	specialRangeTrie = newRangeTrie(specialRanges)
+	rirRangeTrie     = newRIRTrie(rirRanges)
+)
+
+func newRangeTrie(ranges []addressRange) *trie.Trie[addressRange] {
+	t := &trie.Trie[addressRange]{}
+	for _, r := range ranges {
+		t.InsertNet(r.network, r)
+	}
+
+	return t
+}
+
+func newRIRTrie(ranges []rirRange) *trie.Trie[string] {
+	t := &trie.Trie[string]{}
+	for _, r := range ranges {
+		t.InsertNet(r.network, r.rir)
+	}
+
+	return t
+}
+
 func mustParseCIDR(cidr string) *net.IPNet {
 	_, network, err := net.ParseCIDR(cidr)
 	if err != nil {
@@ -80,19 +179,50 @@ func mustParseCIDR(cidr string) *net.IPNet {
 	return network
 }
 
+// FormatOptions controls optional parts of the formatted text output
+type FormatOptions struct {
+	// HumanCount appends a word-scale host count (e.g. "18.4 quintillion") to the power notation
+	HumanCount bool
+	// NoClass omits the class and address type, and skips classification in Calculate
+	NoClass bool
+	// Mixed renders IPv4-embedded addresses with a dotted-quad tail instead of hextets
+	Mixed bool
+	// BinaryWrap breaks the binary representation onto a new line after this many groups, 0 keeps
+	// it on one line
+	BinaryWrap int
+	// RIR appends the regional registry responsible for major global unicast allocations to the
+	// address type, e.g. "Internet Routable (RIPE NCC)"
+	RIR bool
+}
+
 type Network struct {
-	Address      net.IP
+	Address net.IP
+	// Zone is the scope zone written after the address (RFC 4007), e.g. "eth0" for fe80::1%eth0,
+	// naming the interface a link-local address belongs to
+	Zone         string
 	PrefixLength int
+	Netmask      net.IP
+	Wildcard     net.IP
 	Network      net.IP
 	HostMin      net.IP
 	HostMax      net.IP
 	HostCount    *big.Int
 	Class        string
 	Type         string
+	Format       FormatOptions
+	// CustomRanges are consulted before the built-in ranges when classifying the address type
+	CustomRanges []CustomRange
 }
 
 func ParseCIDR(cidr string) (*Network, error) {
-	ip, ipNet, err := net.ParseCIDR(cidr)
+	address, prefix, _ := strings.Cut(cidr, "/")
+
+	address, zone, hasZone := strings.Cut(address, "%")
+	if hasZone && zone == "" {
+		return nil, fmt.Errorf("%w: empty zone in %q", ErrInvalidAddress, cidr)
+	}
+
+	ip, ipNet, err := net.ParseCIDR(address + "/" + prefix)
 	if err != nil {
 		return nil, fmt.Errorf("net.ParseCIDR: %w", err)
 	}
@@ -101,8 +231,9 @@ func ParseCIDR(cidr string) (*Network, error) {
 		return nil, fmt.Errorf("%w: not an IPv6 address", ErrInvalidAddress)
 	}
 
-	// Ensure it's actually IPv6 and not IPv4
-	if ip.To4() != nil {
+	// Ensure it's actually IPv6 and not IPv4. net.ParseCIDR stores both plain and IPv4-mapped
+	// addresses in the mapped form, so only the notation tells them apart.
+	if ip.To4() != nil && !strings.Contains(cidr, ":") {
 		return nil, fmt.Errorf("%w: IPv4 address provided, expected IPv6", ErrInvalidAddress)
 	}
 
@@ -110,12 +241,50 @@ func ParseCIDR(cidr string) (*Network, error) {
 
 	return &Network{
 		Address:      ip.To16(),
+		Zone:         zone,
 		PrefixLength: prefixLen,
 	}, nil
 }
 
+// String returns the address, any zone, and the prefix length, writing IPv4-mapped addresses in
+// mixed notation (::ffff:192.0.2.1) rather than the bare dotted quad net.IP would give
 func (n *Network) String() string {
-	return fmt.Sprintf("%s/%d", n.Address, n.PrefixLength)
+	address := n.Address.String()
+	if _, ok := mappedIPv4(n.Address); ok {
+		address = FormatMixed(n.Address)
+	}
+
+	if n.Zone != "" {
+		address += "%" + n.Zone
+	}
+
+	return fmt.Sprintf("%s/%d", address, n.PrefixLength)
+}
+
+// CanonicalCIDR returns the network address in compressed hextets and the prefix length with any
+// host bits cleared, e.g. "2001:db8::/32" for 2001:db8::1/32
+func (n *Network) CanonicalCIDR() string {
+	first, _ := n.bounds()
+
+	return fmt.Sprintf("%s/%d", compressIPv6(fromBigInt(first)), n.PrefixLength)
+}
+
+// NetworkAddress returns the network address as shown in the Network row, in compressed hextets
+// or the notation selected by the Mixed format option. Calculate must have been called.
+func (n *Network) NetworkAddress() string {
+	return n.formatAddress(n.Network)
+}
+
+// HostCountString returns the host count as a decimal string. Calculate must have been called.
+func (n *Network) HostCountString() string {
+	return n.HostCount.String()
+}
+
+// ClassType returns the class and address type as shown after the host count, e.g. "Link-Local
+// Unicast, Auto-configured", or an empty string if the NoClass format option is set. Calculate
+// must have been called.
+func (n *Network) ClassType() string {
+	return n.classSummary()
 }
 
 func (n *Network) Calculate() error {
@@ -123,9 +292,15 @@ func (n *Network) Calculate() error {
 		return fmt.Errorf("%w: address is nil", ErrInvalidAddress)
 	}
 
+	if n.PrefixLength < 0 || n.PrefixLength > 128 {
+		return fmt.Errorf("%w: /%d is outside /0 to /128", ErrInvalidPrefix, n.PrefixLength)
+	}
+
+	n.Netmask = calculateIPv6Netmask(n.PrefixLength)
+	n.Wildcard = calculateIPv6Wildcard(n.PrefixLength)
+
 	// Calculate network address
-	mask := net.CIDRMask(n.PrefixLength, 128)
-	n.Network = n.Address.Mask(mask)
+	n.Network = n.Address.Mask(net.IPMask(n.Netmask))
 
 	// Calculate host range (first and last addresses in subnet)
 	n.HostMin, n.HostMax = calculateHostRange(n.Network, n.PrefixLength)
@@ -133,58 +308,55 @@ func (n *Network) Calculate() error {
 	// Calculate host count (for display purposes, though it may be massive)
 	n.HostCount = calculateHostCount(n.PrefixLength)
 
+	// The unspecified address marks the absence of an address, so there's no host to assign
+	if n.IsUnspecified() {
+		n.HostCount = new(big.Int)
+	}
+
 	// Classify the address
-	n.Class, n.Type = classifyAddress(n.Address)
+	if !n.Format.NoClass {
+		n.Class, n.Type = classifyAddress(n.Address, n.Format.RIR)
+
+		if label, ok := n.customLabel(); ok {
+			n.Type = label
+		}
+
+		// The default route covers every address, so a range class or type would be misleading
+		if n.PrefixLength == 0 {
+			n.Class, n.Type = "", addressTypeDefaultRoute.String()
+		}
+	}
 
 	return nil
 }
 
 func (n *Network) FormattedText() string {
-	// Format addresses (no binary, no mask - clean default format)
-	addressCompressed := compressIPv6(n.Address)
-	networkStr := fmt.Sprintf("%s/%d", compressIPv6(n.Network), n.PrefixLength)
-	
-	// For display purposes, limit host count to avoid enormous numbers
-	hostCountStr := formatHostCount(n.HostCount, n.PrefixLength)
-
-	separator := calculateSeparatorLength(false)
-	
-	return fmt.Sprintf(
-		""+
-			"   Address:\t%-40s\n"+
-			"    Prefix:\t%-40s\n"+
-			"%s\n"+
-			"   Network:\t%-40s\n"+
-			"First host:\t%-40s\n"+
-			" Last host:\t%-40s\n"+
-			"Host count:\t%-40s\t%s, %s",
-		addressCompressed,
-		fmt.Sprintf("/%d", n.PrefixLength),
-		separator,
-		networkStr,
-		compressIPv6(n.HostMin),
-		compressIPv6(n.HostMax),
-		hostCountStr, n.Class, n.Type,
-	)
+	return layout.Rows([]layout.Row{
+		{Label: "Address", Value: n.addressText()},
+		{Label: "Prefix", Value: fmt.Sprintf("/%d", n.PrefixLength)},
+		layout.Separator,
+		{Label: "Network", Value: fmt.Sprintf("%s/%d", n.formatAddress(n.Network), n.PrefixLength)},
+		{Label: "First host", Value: n.hostText(n.HostMin)},
+		{Label: "Last host", Value: n.hostText(n.HostMax)},
+		{Label: "Host count", Value: n.hostCountText(), Extra: n.classSummary()},
+	}, n.scopeRow()+n.contextRows()+n.notes())
 }
 
 func (n *Network) FormattedTextWithBinary() string {
 	// Format addresses with binary representations
-	addressCompressed := compressIPv6(n.Address)
-	networkStr := fmt.Sprintf("%s/%d", compressIPv6(n.Network), n.PrefixLength)
-	
+	addressCompressed := n.addressText()
+	networkStr := fmt.Sprintf("%s/%d", n.formatAddress(n.Network), n.PrefixLength)
+
 	// Format binary representations with network/host boundary
-	addressBinary := FormatBinaryWithMask(n.Address, n.PrefixLength)
-	networkBinary := FormatBinaryWithMask(n.Network, n.PrefixLength)
-	hostMinBinary := FormatBinaryWithMask(n.HostMin, n.PrefixLength)
-	hostMaxBinary := FormatBinaryWithMask(n.HostMax, n.PrefixLength)
+	addressBinary := n.wrapBinary(FormatBinaryWithMask(n.Address, n.PrefixLength))
+	networkBinary := n.wrapBinary(FormatBinaryWithMask(n.Network, n.PrefixLength))
+	hostMinBinary := n.wrapBinary(FormatBinaryWithMask(n.HostMin, n.PrefixLength))
+	hostMaxBinary := n.wrapBinary(FormatBinaryWithMask(n.HostMax, n.PrefixLength))
 
 	// For display purposes, limit host count to avoid enormous numbers
-	hostCountStr := formatHostCount(n.HostCount, n.PrefixLength)
+	hostCountStr := n.hostCountText()
 
-	separator := calculateSeparatorLength(true)
-	
-	return fmt.Sprintf(
+	return layout.DrawSeparator(fmt.Sprintf(
 		""+
 			"   Address:\t%-40s\t%s\n"+
 			"    Prefix:\t%-40s\n"+
@@ -192,40 +364,34 @@ func (n *Network) FormattedTextWithBinary() string {
 			"   Network:\t%-40s\t%s\n"+
 			"First host:\t%-40s\t%s\n"+
 			" Last host:\t%-40s\t%s\n"+
-			"Host count:\t%-40s\t%s, %s",
+			"Host count:\t%s",
 		addressCompressed, addressBinary,
 		fmt.Sprintf("/%d", n.PrefixLength),
-		separator,
+		layout.SeparatorPlaceholder,
 		networkStr, networkBinary,
-		compressIPv6(n.HostMin), hostMinBinary,
-		compressIPv6(n.HostMax), hostMaxBinary,
-		hostCountStr, n.Class, n.Type,
-	)
+		n.hostText(n.HostMin), hostMinBinary,
+		n.hostText(n.HostMax), hostMaxBinary,
+		n.hostCountSummary(hostCountStr),
+	) + n.scopeRow() + n.contextRows() + n.notes())
 }
 
 func (n *Network) FormattedTextWithMask() string {
-	// Calculate netmask and wildcard
-	netmask := calculateIPv6Netmask(n.PrefixLength)
-	wildcard := calculateIPv6Wildcard(n.PrefixLength)
-	
 	// Format addresses
-	addressCompressed := compressIPv6(n.Address)
-	networkStr := fmt.Sprintf("%s/%d", compressIPv6(n.Network), n.PrefixLength)
-	
+	addressCompressed := n.addressText()
+	networkStr := fmt.Sprintf("%s/%d", n.formatAddress(n.Network), n.PrefixLength)
+
 	// Format binary representations with network/host boundary
-	addressBinary := FormatBinaryWithMask(n.Address, n.PrefixLength)
-	netmaskBinary := FormatBinaryWithMask(netmask, n.PrefixLength)
-	wildcardBinary := FormatBinaryWithMask(wildcard, n.PrefixLength)
-	networkBinary := FormatBinaryWithMask(n.Network, n.PrefixLength)
-	hostMinBinary := FormatBinaryWithMask(n.HostMin, n.PrefixLength)
-	hostMaxBinary := FormatBinaryWithMask(n.HostMax, n.PrefixLength)
+	addressBinary := n.wrapBinary(FormatBinaryWithMask(n.Address, n.PrefixLength))
+	netmaskBinary := n.wrapBinary(FormatBinaryWithMask(n.Netmask, n.PrefixLength))
+	wildcardBinary := n.wrapBinary(FormatBinaryWithMask(n.Wildcard, n.PrefixLength))
+	networkBinary := n.wrapBinary(FormatBinaryWithMask(n.Network, n.PrefixLength))
+	hostMinBinary := n.wrapBinary(FormatBinaryWithMask(n.HostMin, n.PrefixLength))
+	hostMaxBinary := n.wrapBinary(FormatBinaryWithMask(n.HostMax, n.PrefixLength))
 
 	// For display purposes, limit host count to avoid enormous numbers
-	hostCountStr := formatHostCount(n.HostCount, n.PrefixLength)
+	hostCountStr := n.hostCountText()
 
-	separator := calculateSeparatorLength(true)
-	
-	return fmt.Sprintf(
+	return layout.DrawSeparator(fmt.Sprintf(
 		""+
 			"   Address:\t%-40s\t%s\n"+
 			"    Prefix:\t%-40s\n"+
@@ -235,51 +401,31 @@ func (n *Network) FormattedTextWithMask() string {
 			"   Network:\t%-40s\t%s\n"+
 			"First host:\t%-40s\t%s\n"+
 			" Last host:\t%-40s\t%s\n"+
-			"Host count:\t%-40s\t%s, %s",
+			"Host count:\t%s",
 		addressCompressed, addressBinary,
 		fmt.Sprintf("/%d", n.PrefixLength),
-		compressIPv6(netmask), netmaskBinary,
-		compressIPv6(wildcard), wildcardBinary,
-		separator,
+		compressIPv6(n.Netmask), netmaskBinary,
+		compressIPv6(n.Wildcard), wildcardBinary,
+		layout.SeparatorPlaceholder,
 		networkStr, networkBinary,
-		compressIPv6(n.HostMin), hostMinBinary,
-		compressIPv6(n.HostMax), hostMaxBinary,
-		hostCountStr, n.Class, n.Type,
-	)
+		n.hostText(n.HostMin), hostMinBinary,
+		n.hostText(n.HostMax), hostMaxBinary,
+		n.hostCountSummary(hostCountStr),
+	) + n.scopeRow() + n.contextRows() + n.notes())
 }
 
 func (n *Network) FormattedTextWithMaskNoBinary() string {
-	// Calculate netmask and wildcard
-	netmask := calculateIPv6Netmask(n.PrefixLength)
-	wildcard := calculateIPv6Wildcard(n.PrefixLength)
-	
-	// Format addresses
-	addressCompressed := compressIPv6(n.Address)
-	networkStr := fmt.Sprintf("%s/%d", compressIPv6(n.Network), n.PrefixLength)
-
-	// For display purposes, limit host count to avoid enormous numbers
-	hostCountStr := formatHostCount(n.HostCount, n.PrefixLength)
-
-	return fmt.Sprintf(
-		""+
-			"   Address:\t%-40s\n"+
-			"    Prefix:\t%-40s\n"+
-			"   Netmask:\t%-40s\n"+
-			"  Wildcard:\t%-40s\n"+
-			"----------------------------------------------------------------------------\n"+
-			"   Network:\t%-40s\n"+
-			"First host:\t%-40s\n"+
-			" Last host:\t%-40s\n"+
-			"Host count:\t%-40s\t%s, %s",
-		addressCompressed,
-		fmt.Sprintf("/%d", n.PrefixLength),
-		compressIPv6(netmask),
-		compressIPv6(wildcard),
-		networkStr,
-		compressIPv6(n.HostMin),
-		compressIPv6(n.HostMax),
-		hostCountStr, n.Class, n.Type,
-	)
+	return layout.Rows([]layout.Row{
+		{Label: "Address", Value: n.addressText()},
+		{Label: "Prefix", Value: fmt.Sprintf("/%d", n.PrefixLength)},
+		{Label: "Netmask", Value: compressIPv6(n.Netmask)},
+		{Label: "Wildcard", Value: compressIPv6(n.Wildcard)},
+		layout.Separator,
+		{Label: "Network", Value: fmt.Sprintf("%s/%d", n.formatAddress(n.Network), n.PrefixLength)},
+		{Label: "First host", Value: n.hostText(n.HostMin)},
+		{Label: "Last host", Value: n.hostText(n.HostMax)},
+		{Label: "Host count", Value: n.hostCountText(), Extra: n.classSummary()},
+	}, n.scopeRow()+n.contextRows()+n.notes())
 }
 
 func calculateHostRange(network net.IP, prefixLen int) (net.IP, net.IP) {
@@ -290,27 +436,17 @@ func calculateHostRange(network net.IP, prefixLen int) (net.IP, net.IP) {
 	copy(hostMin, network)
 	copy(hostMax, network)
 
-	// Calculate the inverse mask
 	hostBits := 128 - prefixLen
 	if hostBits <= 0 {
 		// Single address
 		return hostMin, hostMax
 	}
 
-	// Set all host bits to 1 in hostMax
-	bytesToFill := hostBits / 8
-	remainingBits := hostBits % 8
-
-	// Start from the end and work backwards
-	byteIndex := 15
-	for range bytesToFill {
-		hostMax[byteIndex] = 0xFF
-		byteIndex--
-	}
-
-	if remainingBits > 0 && byteIndex >= 0 {
-		mask := byte((1 << remainingBits) - 1)
-		hostMax[byteIndex] |= mask
+	// Set all host bits to 1 in hostMax by ORing the inverse mask onto every byte, which keeps the
+	// network bits of a partial byte for prefixes that aren't on a byte boundary (e.g. /66, /69)
+	mask := net.CIDRMask(prefixLen, 128)
+	for i := range hostMax {
+		hostMax[i] |= ^mask[i]
 	}
 
 	return hostMin, hostMax
@@ -329,22 +465,43 @@ func calculateHostCount(prefixLen int) *big.Int {
 	return result
 }
 
-func classifyAddress(ip net.IP) (string, string) {
-	// Check special ranges in order of specificity
-	for _, r := range specialRanges {
-		if r.network.Contains(ip) {
-			// Special handling for multicast to include scope
-			if r.typ == addressTypeMulticast {
-				scope := getMulticastScope(ip)
-				return fmt.Sprintf("Multicast %s", scope), r.typ.String()
-			}
+func classifyAddress(ip net.IP, withRIR bool) (string, string) {
+	r, ok := specialRangeTrie.Lookup(ip.To16())
+	if !ok {
+		// Default to reserved if no match
+		return "Reserved", addressTypeReserved.String()
+	}
 
-			return r.class, r.typ.String()
-		}
+	// Special handling for multicast to include scope
+	if r.typ == addressTypeMulticast {
+		scope := getMulticastScope(ip)
+		return fmt.Sprintf("Multicast %s", scope), r.typ.String()
+	}
+
+	if r.typ == addressTypeGlobalUnicast && withRIR {
+		return r.class, r.typ.String() + registrySuffix(ip)
+	}
+
+	return r.class, r.typ.String()
+}
+
+// classifyAddressType returns the address type of the most specific special range containing ip
+func classifyAddressType(ip net.IP) addressType {
+	if r, ok := specialRangeTrie.Lookup(ip.To16()); ok {
+		return r.typ
+	}
+
+	return addressTypeReserved
+}
+
+// registrySuffix returns the responsible regional registry in parentheses, or an empty string if
+// the address isn't in one of the major allocations
+func registrySuffix(ip net.IP) string {
+	if rir, ok := rirRangeTrie.Lookup(ip.To16()); ok {
+		return fmt.Sprintf(" (%s)", rir)
 	}
 
-	// Default to reserved if no match
-	return "Reserved", addressTypeReserved.String()
+	return ""
 }
 
 func getMulticastScope(ip net.IP) string {
@@ -373,7 +530,51 @@ func getMulticastScope(ip net.IP) string {
 	}
 }
 
+// bounds returns the first and last addresses of the network as integers, derived from the address
+// and prefix length so it doesn't depend on Calculate having been called
+func (n *Network) bounds() (*big.Int, *big.Int) {
+	hostMask := new(big.Int).Lsh(big.NewInt(1), uint(128-n.PrefixLength))
+	hostMask.Sub(hostMask, big.NewInt(1))
+
+	first := new(big.Int).AndNot(toBigInt(n.Address), hostMask)
+
+	return first, new(big.Int).Or(first, hostMask)
+}
+
+// toBigInt converts a 16-byte IPv6 address to its integer value
+func toBigInt(ip net.IP) *big.Int {
+	return new(big.Int).SetBytes(ip.To16())
+}
+
+// fromBigInt converts an integer value to a 16-byte IPv6 address
+func fromBigInt(v *big.Int) net.IP {
+	ip := make(net.IP, 16)
+	v.FillBytes(ip)
+
+	return ip
+}
+
+// formatAddress renders an address for display, using the notation selected by the Mixed format
+// option for IPv4-embedded addresses
+func (n *Network) formatAddress(ip net.IP) string {
+	if !isIPv4Embedded(ip) {
+		return compressIPv6(ip)
+	}
+
+	if n.Format.Mixed {
+		return FormatMixed(ip)
+	}
+
+	return FormatHextets(ip)
+}
+
 func compressIPv6(ip net.IP) string {
+	// net.IP.String renders anything in ::ffff:0:0/96 as a bare dotted quad, which would turn
+	// values like the /80 wildcard ::ffff:ffff:ffff into 255.255.255.255
+	if ip.To4() != nil {
+		return FormatHextets(ip)
+	}
+
 	// Use Go's built-in IPv6 compression
 	return ip.String()
 }
@@ -393,6 +594,225 @@ func formatHostCount(count *big.Int, prefixLen int) string {
 	}
 }
 
+// HCLText returns the network as a Terraform/HCL object literal, quoting strings and leaving
+// numbers bare
+func (n *Network) HCLText() string {
+	return fmt.Sprintf(
+		`{ cidr = "%s/%d", netmask = "%s", network = "%s", host_min = "%s", host_max = "%s", hosts = %s }`,
+		n.formatAddress(n.Network), n.PrefixLength, compressIPv6(n.Netmask),
+		n.formatAddress(n.Network), n.formatAddress(n.HostMin), n.formatAddress(n.HostMax), n.HostCount,
+	)
+}
+
+// IsUnspecified reports whether the network is the unspecified address ::/128 (RFC 4291), which a
+// host uses as its source address before it has one configured. It's distinct from the default
+// route ::/0.
+func (n *Network) IsUnspecified() bool {
+	return n.PrefixLength == 128 && n.Address.Equal(net.IPv6unspecified)
+}
+
+// addressText renders the address for the Address row, followed by its zone if it has one, e.g.
+// "fe80::1%eth0"
+func (n *Network) addressText() string {
+	if n.Zone != "" {
+		return n.formatAddress(n.Address) + "%" + n.Zone
+	}
+
+	return n.formatAddress(n.Address)
+}
+
+// hostText renders a first or last host address for display, or "none" for the unspecified
+// address, which can't be assigned to a host
+func (n *Network) hostText(ip net.IP) string {
+	if n.IsUnspecified() {
+		return "none"
+	}
+
+	return n.formatAddress(ip)
+}
+
+// HasHostBits reports whether the address has bits set below the prefix boundary, i.e. it isn't
+// the network address
+func (n *Network) HasHostBits() bool {
+	return !n.Address.Equal(n.Network)
+}
+
+// CheckNetworkAddress returns ErrInvalidAddress if the address has host bits set, naming the
+// network it should be written as, e.g. 2001:db8::/64 for 2001:db8::1/64, so configs can be
+// linted to express networks cleanly. Calculate must have been called.
+func (n *Network) CheckNetworkAddress() error {
+	if n.HasHostBits() {
+		return fmt.Errorf("%w: %s has host bits set; the network is %s", ErrInvalidAddress, n, n.CanonicalCIDR())
+	}
+
+	return nil
+}
+
+// NeedsZone reports whether the network names a link-local host address without a zone. The same
+// link-local address can be on every interface, so hosts with several need the zone, e.g.
+// fe80::1%eth0, to know which link is meant. Link-local prefixes such as fe80::/64 don't.
+func (n *Network) NeedsZone() bool {
+	isHost := n.PrefixLength == 128 || n.HasHostBits()
+
+	return n.Zone == "" && isHost && n.Address.IsLinkLocalUnicast()
+}
+
+// scopeRow returns the Scope line appended after the host count, or an empty string if the
+// NoClass format option is set or the network is the default route, which spans every scope
+func (n *Network) scopeRow() string {
+	if n.Format.NoClass || n.PrefixLength == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n     Scope:\t%s", n.Scope())
+}
+
+// notes returns informational lines appended to the formatted text
+func (n *Network) notes() string {
+	var b strings.Builder
+
+	if n.HasHostBits() {
+		fmt.Fprintf(&b, "\n      Note:\thost bits set; network is %s/%d", n.formatAddress(n.Network), n.PrefixLength)
+	}
+
+	if name, deprecated, ok := n.TransitionMechanism(); ok {
+		status := "in use"
+		if deprecated {
+			status = "deprecated"
+		}
+
+		fmt.Fprintf(&b, "\n      Note:\t%s transition address (%s)", name, status)
+	}
+
+	if ip4, ok := mappedIPv4(n.Address); ok {
+		fmt.Fprintf(&b, "\n      Note:\tIPv4-mapped address for %s", ip4)
+	}
+
+	if n.NeedsZone() {
+		fmt.Fprintf(&b, "\n      Note:\tlink-local address without a zone; name the interface, e.g. %s%%eth0",
+			n.formatAddress(n.Address))
+	}
+
+	// Link-local addresses are always formed by SLAAC from a /64 (RFC 4291 section 2.5.6)
+	if n.Address.IsLinkLocalUnicast() && n.PrefixLength > lanPrefixLength && n.PrefixLength < 128 {
+		fmt.Fprintf(&b, "\n      Note:\tlink-local links are /64; /%d breaks SLAAC", n.PrefixLength)
+	}
+
+	if n.IsUnspecified() {
+		b.WriteString("\n      Note:\tunspecified address, used as a source address before configuration")
+	}
+
+	if n.PrefixLength == 0 {
+		first, last := n.bounds()
+		fmt.Fprintf(&b, "\n      Note:\tdefault route spanning %s to %s",
+			n.formatAddress(fromBigInt(first)), n.formatAddress(fromBigInt(last)))
+	}
+
+	return b.String()
+}
+
+// wrapBinary splits a binary representation after every BinaryWrap groups, indenting the
+// continuation lines under the binary column
+func (n *Network) wrapBinary(binary string) string {
+	if n.Format.BinaryWrap <= 0 {
+		return binary
+	}
+
+	groups := strings.Split(binary, ":")
+
+	var lines []string
+	for len(groups) > n.Format.BinaryWrap {
+		lines = append(lines, strings.Join(groups[:n.Format.BinaryWrap], ":")+":")
+		groups = groups[n.Format.BinaryWrap:]
+	}
+
+	lines = append(lines, strings.Join(groups, ":"))
+
+	// Skip the label and value columns: the label tab stop, the padded value and its tab
+	return strings.Join(lines, "\n\t\t"+strings.Repeat(" ", 40)+"\t")
+}
+
+// hostCountSummary returns the host count followed by the class and address type, unless the
+// NoClass format option is set
+func (n *Network) hostCountSummary(hostCount string) string {
+	if n.Format.NoClass {
+		return hostCount
+	}
+
+	return fmt.Sprintf("%-40s\t%s", hostCount, n.classSummary())
+}
+
+// classSummary returns the class and address type shown after the host count, or an empty string
+// if the NoClass format option is set
+func (n *Network) classSummary() string {
+	if n.Format.NoClass {
+		return ""
+	}
+
+	if n.PrefixLength == 0 {
+		return n.Type + " (all addresses)"
+	}
+
+	return fmt.Sprintf("%s, %s", n.Class, n.Type)
+}
+
+// hostCountText returns the host count for display, honouring the HumanCount format option
+func (n *Network) hostCountText() string {
+	if !n.Format.HumanCount || n.HostCount.Cmp(countScales[0].value) < 0 {
+		return formatHostCount(n.HostCount, n.PrefixLength)
+	}
+
+	return fmt.Sprintf("2^%d (%s)", 128-n.PrefixLength, HumanizeCount(n.HostCount))
+}
+
+type countScale struct {
+	value *big.Int
+	name  string
+}
+
+// countScales lists the short-scale number names in ascending order
+var countScales = func() []countScale {
+	names := []string{
+		"million", "billion", "trillion", "quadrillion", "quintillion", "sextillion",
+		"septillion", "octillion", "nonillion", "decillion", "undecillion",
+	}
+
+	scales := make([]countScale, 0, len(names))
+	value := big.NewInt(1_000_000)
+
+	for _, name := range names {
+		scales = append(scales, countScale{value: new(big.Int).Set(value), name: name})
+		value.Mul(value, big.NewInt(1000))
+	}
+
+	return scales
+}()
+
+// HumanizeCount renders a count using word-scale suffixes, e.g. "18.4 quintillion".
+// The fraction is truncated to one decimal place so capacity is never overstated.
+// Counts below one million are returned as plain integers.
+func HumanizeCount(c *big.Int) string {
+	if c == nil {
+		return ""
+	}
+
+	for i := len(countScales) - 1; i >= 0; i-- {
+		scale := countScales[i]
+		if c.Cmp(scale.value) < 0 {
+			continue
+		}
+
+		tenths := new(big.Int).Mul(c, big.NewInt(10))
+		tenths.Quo(tenths, scale.value)
+
+		whole, frac := new(big.Int).QuoRem(tenths, big.NewInt(10), new(big.Int))
+
+		return fmt.Sprintf("%s.%s %s", whole, frac, scale.name)
+	}
+
+	return c.String()
+}
+
 // FormatBinary returns the full 128-bit binary representation of an IPv6 address
 func FormatBinary(ip net.IP) string {
 	if len(ip) != 16 {
@@ -460,7 +880,7 @@ func calculateIPv6Netmask(prefixLen int) net.IP {
 	return net.IP(mask)
 }
 
-// calculateIPv6Wildcard returns the IPv6 wildcard (inverse mask) for a given prefix length  
+// calculateIPv6Wildcard returns the IPv6 wildcard (inverse mask) for a given prefix length
 func calculateIPv6Wildcard(prefixLen int) net.IP {
 	mask := net.CIDRMask(prefixLen, 128)
 	wildcard := make(net.IP, 16)
@@ -469,17 +889,3 @@ func calculateIPv6Wildcard(prefixLen int) net.IP {
 	}
 	return wildcard
 }
-
-// calculateSeparatorLength determines the appropriate separator line length based on content
-func calculateSeparatorLength(hasBinary bool) string {
-	if hasBinary {
-		// With binary, the line is much longer: 
-		// "   Address:\t" + 40 chars + "\t" + 128-bit binary (roughly 145 chars)
-		// Total roughly 200+ characters
-		return strings.Repeat("-", 200)
-	} else {
-		// Without binary, just the address part:
-		// "   Address:\t" + 40 chars = roughly 50 characters
-		return strings.Repeat("-", 76)
-	}
-}
@@ -1,13 +1,43 @@
 package ipv6_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"net"
+	"strings"
 	"testing"
 
 	"github.com/ronny/ripcalc/ipv6"
 )
 
+func TestParseCIDR_IPv4Mapped(t *testing.T) {
+	network, err := ipv6.ParseCIDR("::ffff:192.168.1.1/128")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if network.Class != "IPv4-Mapped" || network.Type != "Embedded IPv4" {
+		t.Errorf("Class, Type = %q, %q, want %q, %q", network.Class, network.Type, "IPv4-Mapped", "Embedded IPv4")
+	}
+
+	if got := network.String(); got != "::ffff:192.168.1.1/128" {
+		t.Errorf("String() = %q, want %q", got, "::ffff:192.168.1.1/128")
+	}
+
+	output := network.FormattedText()
+	for _, element := range []string{"::ffff:c0a8:101/128", "Note:\tIPv4-mapped address for 192.168.1.1"} {
+		if !strings.Contains(output, element) {
+			t.Errorf("FormattedText() missing %q:\n%s", element, output)
+		}
+	}
+}
+
 func TestParseCIDR(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -350,3 +380,593 @@ func TestMulticastClassification(t *testing.T) {
 		})
 	}
 }
+
+func TestHumanizeCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidr     string
+		expected string
+	}{
+		{
+			name:     "/96 is about 4.2 billion",
+			cidr:     "2001:db8::/96",
+			expected: "4.2 billion",
+		},
+		{
+			name:     "/64 is about 18.4 quintillion",
+			cidr:     "2001:db8::/64",
+			expected: "18.4 quintillion",
+		},
+		{
+			name:     "/0 is about 340.2 undecillion",
+			cidr:     "::/0",
+			expected: "340.2 undecillion",
+		},
+		{
+			name:     "/120 is below a million",
+			cidr:     "2001:db8::/120",
+			expected: "256",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() unexpected error: %v", err)
+			}
+
+			err = network.Calculate()
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+
+			result := ipv6.HumanizeCount(network.HostCount)
+			if result != tt.expected {
+				t.Errorf("HumanizeCount() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormattedTextHumanCount(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	if output := network.FormattedText(); containsString(output, "quintillion") {
+		t.Errorf("FormattedText() should not include human count by default, got:\n%s", output)
+	}
+
+	network.Format.HumanCount = true
+
+	output := network.FormattedText()
+	if !containsString(output, "2^64 (18.4 quintillion)") {
+		t.Errorf("FormattedText() missing human count, got:\n%s", output)
+	}
+}
+
+func TestFormattedTextSeparatorWidth(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::1/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	outputs := map[string]string{
+		"FormattedText":                 network.FormattedText(),
+		"FormattedTextWithBinary":       network.FormattedTextWithBinary(),
+		"FormattedTextWithMask":         network.FormattedTextWithMask(),
+		"FormattedTextWithMaskNoBinary": network.FormattedTextWithMaskNoBinary(),
+	}
+
+	for name, output := range outputs {
+		t.Run(name, func(t *testing.T) {
+			separator, longest := separatorAndLongestRow(output)
+			if separator == 0 {
+				t.Fatalf("%s() has no separator line:\n%s", name, output)
+			}
+
+			if separator != longest {
+				t.Errorf("%s() separator width = %d, expected %d", name, separator, longest)
+			}
+		})
+	}
+}
+
+// separatorAndLongestRow returns the width of the dashed separator line and the display width
+// (with 8-column tab stops) of the longest content row
+func separatorAndLongestRow(output string) (int, int) {
+	separator, longest := 0, 0
+
+	for line := range strings.SplitSeq(output, "\n") {
+		if strings.Trim(line, "-") == "" {
+			separator = len(line)
+			continue
+		}
+
+		width := 0
+
+		for _, r := range strings.TrimRight(line, " ") {
+			if r == '\t' {
+				width += 8 - width%8
+			} else {
+				width++
+			}
+		}
+
+		longest = max(longest, width)
+	}
+
+	return separator, longest
+}
+
+func TestCalculateInvalidPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefixLength int
+	}{
+		{
+			name:         "prefix too long",
+			prefixLength: 200,
+		},
+		{
+			name:         "negative prefix",
+			prefixLength: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := &ipv6.Network{
+				Address:      net.ParseIP("2001:db8::1"),
+				PrefixLength: tt.prefixLength,
+			}
+
+			err := network.Calculate()
+			if !errors.Is(err, ipv6.ErrInvalidPrefix) {
+				t.Errorf("Calculate() error = %v, expected %v", err, ipv6.ErrInvalidPrefix)
+			}
+		})
+	}
+}
+
+func TestFormattedTextNoClass(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	network.Format.NoClass = true
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	outputs := map[string]string{
+		"FormattedText":                 network.FormattedText(),
+		"FormattedTextWithBinary":       network.FormattedTextWithBinary(),
+		"FormattedTextWithMask":         network.FormattedTextWithMask(),
+		"FormattedTextWithMaskNoBinary": network.FormattedTextWithMaskNoBinary(),
+	}
+
+	for name, output := range outputs {
+		if strings.Contains(output, "Documentation") || strings.Contains(output, "RFC Example") {
+			t.Errorf("%s() contains classification:\n%s", name, output)
+		}
+
+		if !strings.HasSuffix(output, "Host count:\t2^64") {
+			t.Errorf("%s() missing bare host count:\n%s", name, output)
+		}
+	}
+}
+
+func TestHCLText(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::1/120")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	expected := `{ cidr = "2001:db8::/120", netmask = "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ff00", ` +
+		`network = "2001:db8::", host_min = "2001:db8::", host_max = "2001:db8::ff", hosts = 256 }`
+
+	if result := network.HCLText(); result != expected {
+		t.Errorf("HCLText() = %v, expected %v", result, expected)
+	}
+}
+
+func TestHostBitsNotice(t *testing.T) {
+	tests := []struct {
+		name           string
+		cidr           string
+		expectedNotice bool
+	}{
+		{
+			name:           "host bits set",
+			cidr:           "2001:db8::5/64",
+			expectedNotice: true,
+		},
+		{
+			name:           "aligned network",
+			cidr:           "2001:db8::/64",
+			expectedNotice: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() unexpected error: %v", err)
+			}
+
+			err = network.Calculate()
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+
+			if network.HasHostBits() != tt.expectedNotice {
+				t.Errorf("HasHostBits() = %v, expected %v", network.HasHostBits(), tt.expectedNotice)
+			}
+
+			err = network.CheckNetworkAddress()
+			if tt.expectedNotice != errors.Is(err, ipv6.ErrInvalidAddress) {
+				t.Errorf("CheckNetworkAddress() error = %v, expected ErrInvalidAddress %v", err, tt.expectedNotice)
+			}
+
+			hasNotice := strings.Contains(network.FormattedText(), "host bits set; network is 2001:db8::/64")
+			if hasNotice != tt.expectedNotice {
+				t.Errorf("notice present = %v, expected %v", hasNotice, tt.expectedNotice)
+			}
+		})
+	}
+}
+
+func TestParseCIDR_Zone(t *testing.T) {
+	tests := []struct {
+		cidr       string
+		wantZone   string
+		wantString string
+		wantErr    bool
+	}{
+		{"fe80::1%eth0/64", "eth0", "fe80::1%eth0/64", false},
+		{"fe80::1/64", "", "fe80::1/64", false},
+		{"fe80::1%/64", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if tt.wantErr {
+				if !errors.Is(err, ipv6.ErrInvalidAddress) {
+					t.Errorf("ParseCIDR() error = %v, expected ErrInvalidAddress", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseCIDR() unexpected error: %v", err)
+			}
+
+			if network.Zone != tt.wantZone {
+				t.Errorf("Zone = %q, expected %q", network.Zone, tt.wantZone)
+			}
+
+			if network.String() != tt.wantString {
+				t.Errorf("String() = %q, expected %q", network.String(), tt.wantString)
+			}
+		})
+	}
+}
+
+func TestZoneNotice(t *testing.T) {
+	tests := []struct {
+		cidr           string
+		expectedNotice bool
+	}{
+		{"fe80::1/64", true},
+		{"fe80::1/128", true},
+		{"fe80::1%eth0/64", false},
+		{"fe80::/64", false},
+		{"2001:db8::1/64", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() unexpected error: %v", err)
+			}
+
+			err = network.Calculate()
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+
+			if network.NeedsZone() != tt.expectedNotice {
+				t.Errorf("NeedsZone() = %v, expected %v", network.NeedsZone(), tt.expectedNotice)
+			}
+
+			hasNotice := strings.Contains(network.FormattedText(), "link-local address without a zone")
+			if hasNotice != tt.expectedNotice {
+				t.Errorf("notice present = %v, expected %v", hasNotice, tt.expectedNotice)
+			}
+		})
+	}
+}
+
+func TestCalculate_HostMaxNonAlignedPrefixes(t *testing.T) {
+	tests := []string{
+		"2001:db8:0:0:ffff::/66",
+		"2001:db8:0:0:a5a5::/69",
+		"2001:db8:abcd:1234:5678:9abc:def0:1234/61",
+		"2001:db8::ffff:ffff:ffff:f000/117",
+		"2001:db8::1/127",
+		"8000::/1",
+	}
+
+	for _, cidr := range tests {
+		t.Run(cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			// Independently set every host bit on the network address
+			hostBits := uint(128 - network.PrefixLength)
+			hostMask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), hostBits), big.NewInt(1))
+			want := new(big.Int).Or(new(big.Int).SetBytes(network.Network.To16()), hostMask)
+			expected := net.IP(want.FillBytes(make([]byte, 16)))
+
+			if !network.HostMax.Equal(expected) {
+				t.Errorf("HostMax = %v, want %v", network.HostMax, expected)
+			}
+		})
+	}
+}
+
+func TestFormattedTextWithBinary_BinaryWrap(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	network.Format.BinaryWrap = 4
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	lines := strings.Split(network.FormattedTextWithBinary(), "\n")
+
+	first := "   Address:\t2001:db8::                              \t" +
+		"0010000000000001:0000110110111000:0000000000000000:0000000000000000:"
+	continuation := "\t\t" + strings.Repeat(" ", 40) + "\t" +
+		" 0000000000000000:0000000000000000:0000000000000000:0000000000000000"
+
+	if lines[0] != first {
+		t.Errorf("first line = %q, want %q", lines[0], first)
+	}
+
+	if lines[1] != continuation {
+		t.Errorf("continuation line = %q, want %q", lines[1], continuation)
+	}
+
+	if first, next := binaryColumn(lines[0]), binaryColumn(lines[1]); first != 64 || next != first {
+		t.Errorf("binary columns = %d and %d, want both at 64", first, next)
+	}
+
+	separator, longest := separatorAndLongestRow(strings.Join(lines, "\n"))
+	if separator != longest {
+		t.Errorf("separator length = %d, longest row = %d", separator, longest)
+	}
+}
+
+// displayWidth returns the columns s occupies with 8-column tab stops
+func displayWidth(s string) int {
+	width := 0
+
+	for _, r := range s {
+		if r == '\t' {
+			width += 8 - width%8
+		} else {
+			width++
+		}
+	}
+
+	return width
+}
+
+// binaryColumn returns the column the binary representation after a row's last tab starts at
+func binaryColumn(line string) int {
+	return displayWidth(line[:strings.LastIndex(line, "\t")+1])
+}
+
+func TestFormattedTextWithMaskNoBinary_Golden(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::1/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	expected := "" +
+		"   Address:\t2001:db8::1\n" +
+		"    Prefix:\t/64\n" +
+		"   Netmask:\tffff:ffff:ffff:ffff::\n" +
+		"  Wildcard:\t::ffff:ffff:ffff:ffff\n" +
+		strings.Repeat("-", 74) + "\n" +
+		"   Network:\t2001:db8::/64\n" +
+		"First host:\t2001:db8::\n" +
+		" Last host:\t2001:db8::ffff:ffff:ffff:ffff\n" +
+		"Host count:\t2^64                         \tDocumentation, RFC Example\n" +
+		"     Scope:\tGlobal\n" +
+		"      Site:\t2001:db8::/48\n" +
+		"      Note:\thost bits set; network is 2001:db8::/64"
+
+	if output := network.FormattedTextWithMaskNoBinary(); output != expected {
+		t.Errorf("FormattedTextWithMaskNoBinary() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestDefaultRoute(t *testing.T) {
+	network, err := ipv6.ParseCIDR("::/0")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if network.Class != "" || network.Type != "Default Route" {
+		t.Errorf("Class, Type = %q, %q, want \"\", \"Default Route\"", network.Class, network.Type)
+	}
+
+	output := network.FormattedText()
+	for _, element := range []string{"Default Route (all addresses)", "spanning :: to ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"} {
+		if !strings.Contains(output, element) {
+			t.Errorf("Output missing %q\nFull output:\n%s", element, output)
+		}
+	}
+
+	if strings.Contains(output, "Unspecified") {
+		t.Errorf("Default route shouldn't be classified as the unspecified address\nFull output:\n%s", output)
+	}
+}
+
+func TestCalculate_NetmaskAndWildcard(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::1/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if want := net.ParseIP("ffff:ffff:ffff:ffff::"); !network.Netmask.Equal(want) {
+		t.Errorf("Netmask = %v, want %v", network.Netmask, want)
+	}
+
+	if want := net.ParseIP("::ffff:ffff:ffff:ffff"); !network.Wildcard.Equal(want) {
+		t.Errorf("Wildcard = %v, want %v", network.Wildcard, want)
+	}
+}
+
+func TestCalculate_UnspecifiedAndDefaultRoute(t *testing.T) {
+	tests := []struct {
+		cidr          string
+		wantClass     string
+		wantType      string
+		wantHostCount string
+		wantElements  []string
+	}{
+		{
+			cidr:          "::/128",
+			wantClass:     "Unspecified",
+			wantType:      "Unconfigured Source",
+			wantHostCount: "0",
+			wantElements: []string{
+				"First host:\tnone",
+				" Last host:\tnone",
+				"Note:\tunspecified address, used as a source address before configuration",
+			},
+		},
+		{
+			cidr:          "::/0",
+			wantClass:     "",
+			wantType:      "Default Route",
+			wantHostCount: "340282366920938463463374607431768211456",
+			wantElements:  []string{"First host:\t::", "Default Route (all addresses)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if network.Class != tt.wantClass || network.Type != tt.wantType {
+				t.Errorf("Class, Type = %q, %q, want %q, %q", network.Class, network.Type, tt.wantClass, tt.wantType)
+			}
+
+			if got := network.HostCount.String(); got != tt.wantHostCount {
+				t.Errorf("HostCount = %s, want %s", got, tt.wantHostCount)
+			}
+
+			output := network.FormattedText()
+			for _, element := range tt.wantElements {
+				if !strings.Contains(output, element) {
+					t.Errorf("FormattedText() missing %q:\n%s", element, output)
+				}
+			}
+
+			if tt.cidr == "::/0" && strings.Contains(output, "unspecified") {
+				t.Errorf("FormattedText() labels the default route as unspecified:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestFormattedText_Zone(t *testing.T) {
+	network, err := ipv6.ParseCIDR("fe80::1%eth0/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	outputs := map[string]string{
+		"FormattedText":                 network.FormattedText(),
+		"FormattedTextWithBinary":       network.FormattedTextWithBinary(),
+		"FormattedTextWithMask":         network.FormattedTextWithMask(),
+		"FormattedTextWithMaskNoBinary": network.FormattedTextWithMaskNoBinary(),
+	}
+
+	for name, output := range outputs {
+		if !strings.HasPrefix(output, "   Address:\tfe80::1%eth0") {
+			t.Errorf("%s() Address row missing the zone:\n%s", name, output)
+		}
+	}
+
+	output, err := json.Marshal(network)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	if !strings.Contains(string(output), `"address":"fe80::1","zone":"eth0"`) {
+		t.Errorf("json.Marshal() = %s, want a separate zone field", output)
+	}
+}
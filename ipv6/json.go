@@ -0,0 +1,111 @@
+package ipv6
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// SchemaURL identifies the JSON schema the marshalled output follows
+const SchemaURL = "https://github.com/ronny/ripcalc/blob/main/schema/ipv6-v1.json"
+
+type jsonUsableHosts struct {
+	Min string `json:"min"`
+	Max string `json:"max"`
+	// Count is a decimal string because it can exceed what JSON numbers hold precisely
+	Count string `json:"count"`
+}
+
+type jsonNetwork struct {
+	Address      string          `json:"address"`
+	PrefixLength string          `json:"prefix_length"`
+	UsableHosts  jsonUsableHosts `json:"usable_hosts"`
+	Class        string          `json:"class,omitempty"`
+	Type         string          `json:"type,omitempty"`
+	Label        string          `json:"label,omitempty"`
+}
+
+type jsonDocument struct {
+	Schema   string      `json:"$schema"`
+	Address  string      `json:"address"`
+	Zone     string      `json:"zone,omitempty"`
+	Netmask  string      `json:"netmask"`
+	Wildcard string      `json:"wildcard"`
+	Network  jsonNetwork `json:"network"`
+}
+
+// MarshalJSON encodes a calculated network in the ipv6-v1 schema, mirroring the IPv4 document
+// without the broadcast address. The class and type are left out when the NoClass format option is
+// set. Addresses in a custom range have the CUSTOM type and the range's label. A zone goes in its
+// own field, so the address stays a plain IPv6 address.
+func (n *Network) MarshalJSON() ([]byte, error) {
+	doc := jsonDocument{
+		Schema:   SchemaURL,
+		Address:  compressIPv6(n.Address),
+		Zone:     n.Zone,
+		Netmask:  compressIPv6(n.Netmask),
+		Wildcard: compressIPv6(n.Wildcard),
+		Network: jsonNetwork{
+			Address:      compressIPv6(n.Network),
+			PrefixLength: strconv.Itoa(n.PrefixLength),
+			UsableHosts: jsonUsableHosts{
+				Min:   compressIPv6(n.HostMin),
+				Max:   compressIPv6(n.HostMax),
+				Count: n.HostCount.String(),
+			},
+		},
+	}
+
+	if !n.Format.NoClass {
+		doc.Network.Class = n.Class
+		doc.Network.Type = classifyAddressType(n.Address).jsonName()
+
+		if label, ok := n.customLabel(); ok {
+			doc.Network.Type, doc.Network.Label = "CUSTOM", label
+		}
+
+		if n.PrefixLength == 0 {
+			doc.Network.Class, doc.Network.Type, doc.Network.Label = "", addressTypeDefaultRoute.jsonName(), ""
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON decodes a document written by MarshalJSON back into a calculated network, so a
+// later stage of a pipeline can read ripcalc's JSON output. Only the address, any zone and the
+// prefix length are read and everything else is recalculated, keeping the network's format
+// options; a document without a class or type sets NoClass. It returns ErrInvalidAddress for
+// another schema's document.
+func (n *Network) UnmarshalJSON(data []byte) error {
+	var doc jsonDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if doc.Schema != SchemaURL {
+		return fmt.Errorf("%w: $schema %q isn't %s", ErrInvalidAddress, doc.Schema, SchemaURL)
+	}
+
+	address := doc.Address
+	if doc.Zone != "" {
+		address += "%" + doc.Zone
+	}
+
+	parsed, err := ParseCIDR(address + "/" + doc.Network.PrefixLength)
+	if err != nil {
+		return fmt.Errorf("ParseCIDR: %w", err)
+	}
+
+	n.Address, n.Zone, n.PrefixLength = parsed.Address, parsed.Zone, parsed.PrefixLength
+
+	if doc.Network.Class == "" && doc.Network.Type == "" {
+		n.Format.NoClass = true
+	}
+
+	if err := n.Calculate(); err != nil {
+		return fmt.Errorf("Calculate: %w", err)
+	}
+
+	return nil
+}
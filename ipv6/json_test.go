@@ -0,0 +1,104 @@
+package ipv6_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::1/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output, err := json.Marshal(network)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	expected := `{"$schema":"https://github.com/ronny/ripcalc/blob/main/schema/ipv6-v1.json",` +
+		`"address":"2001:db8::1","netmask":"ffff:ffff:ffff:ffff::","wildcard":"::ffff:ffff:ffff:ffff",` +
+		`"network":{"address":"2001:db8::","prefix_length":"64",` +
+		`"usable_hosts":{"min":"2001:db8::","max":"2001:db8::ffff:ffff:ffff:ffff","count":"18446744073709551616"},` +
+		`"class":"Documentation","type":"DOCUMENTATION"}}`
+
+	if string(output) != expected {
+		t.Errorf("json.Marshal() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestMarshalJSONDefaultRoute(t *testing.T) {
+	network, err := ipv6.ParseCIDR("::/0")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output, err := json.Marshal(network)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	if !strings.Contains(string(output), `"type":"DEFAULT_ROUTE"`) || strings.Contains(string(output), `"class"`) {
+		t.Errorf("json.Marshal() = %s, want type DEFAULT_ROUTE and no class", output)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		cidr    string
+		noClass bool
+	}{
+		{"2001:db8::1/64", false},
+		{"fe80::/10", true},
+		{"::/0", false},
+		{"fe80::1%eth0/64", false},
+	} {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.Format.NoClass = tt.noClass
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			output, err := json.Marshal(network)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			var decoded ipv6.Network
+			if err := json.Unmarshal(output, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			if decoded.Zone != network.Zone {
+				t.Errorf("round trip Zone = %q, want %q", decoded.Zone, network.Zone)
+			}
+
+			if decoded.FormattedText() != network.FormattedText() {
+				t.Errorf("round trip FormattedText() =\n%s\nwant\n%s", decoded.FormattedText(), network.FormattedText())
+			}
+		})
+	}
+
+	var decoded ipv6.Network
+	if err := json.Unmarshal([]byte(`{"$schema":"https://github.com/ronny/ripcalc/blob/main/schema/ipv4-v1.json","address":"10.0.0.0"}`), &decoded); !errors.Is(err, ipv6.ErrInvalidAddress) {
+		t.Errorf("json.Unmarshal() of another schema error = %v, want ErrInvalidAddress", err)
+	}
+}
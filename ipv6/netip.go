@@ -0,0 +1,73 @@
+package ipv6
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// FromAddr returns an uncalculated network for the address and prefix length, the net/netip
+// counterpart of ParseCIDR. IPv4 addresses are rejected, while IPv4-mapped ones are accepted as in
+// ParseCIDR.
+func FromAddr(a netip.Addr, prefix int) (*Network, error) {
+	if !a.Is6() {
+		return nil, fmt.Errorf("%w: %s is not an IPv6 address", ErrInvalidAddress, a)
+	}
+
+	if prefix < 0 || prefix > 128 {
+		return nil, fmt.Errorf("%w: /%d is outside 0-128", ErrInvalidPrefix, prefix)
+	}
+
+	return &Network{
+		Address:      a.AsSlice(),
+		PrefixLength: prefix,
+	}, nil
+}
+
+// FromPrefix returns an uncalculated network for the prefix, keeping any host bits in its address
+func FromPrefix(p netip.Prefix) (*Network, error) {
+	if !p.IsValid() {
+		return nil, fmt.Errorf("%w: invalid prefix %s", ErrInvalidPrefix, p)
+	}
+
+	return FromAddr(p.Addr(), p.Bits())
+}
+
+// Addr returns the address as a netip.Addr, or the zero Addr if it isn't set
+func (n *Network) Addr() netip.Addr {
+	addr, ok := netip.AddrFromSlice(n.Address)
+	if !ok {
+		return netip.Addr{}
+	}
+
+	return addr
+}
+
+// Prefix returns the address and prefix length as a netip.Prefix, keeping any host bits
+func (n *Network) Prefix() netip.Prefix {
+	return netip.PrefixFrom(n.Addr(), n.PrefixLength)
+}
+
+// IsValidCIDR reports whether ParseCIDR would accept s, without building a Network. Zones are
+// allowed as in ParseCIDR, and the common notation is checked without allocating.
+func IsValidCIDR(s string) bool {
+	prefix := s
+
+	// netip.ParsePrefix rejects zones, so check one is named and drop it
+	if address, rest, hasZone := strings.Cut(s, "%"); hasZone {
+		zone, bits, _ := strings.Cut(rest, "/")
+		if zone == "" {
+			return false
+		}
+
+		prefix = address + "/" + bits
+	}
+
+	if p, err := netip.ParsePrefix(prefix); err == nil {
+		return !p.Addr().Is4()
+	}
+
+	_, err := ParseCIDR(s)
+
+	return err == nil
+}
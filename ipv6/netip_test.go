@@ -0,0 +1,97 @@
+package ipv6_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestFromPrefix_RoundTrip(t *testing.T) {
+	for _, s := range []string{"2001:db8::/32", "2001:db8::1/64", "::/0", "fe80::1/128", "::ffff:192.0.2.1/128"} {
+		t.Run(s, func(t *testing.T) {
+			prefix := netip.MustParsePrefix(s)
+
+			network, err := ipv6.FromPrefix(prefix)
+			if err != nil {
+				t.Fatalf("FromPrefix() error = %v", err)
+			}
+
+			if network.String() != s {
+				t.Errorf("String() = %q, want %q", network.String(), s)
+			}
+
+			if got := network.Prefix(); got != prefix {
+				t.Errorf("Prefix() = %v, want %v", got, prefix)
+			}
+
+			if got := network.Addr(); got != prefix.Addr() {
+				t.Errorf("Addr() = %v, want %v", got, prefix.Addr())
+			}
+
+			parsed, err := ipv6.ParseCIDR(s)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := parsed.Prefix(); got != prefix {
+				t.Errorf("ParseCIDR().Prefix() = %v, want %v", got, prefix)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Errorf("Calculate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFromAddr_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		addr      netip.Addr
+		prefix    int
+		wantError error
+	}{
+		{"IPv4 address", netip.MustParseAddr("192.0.2.1"), 24, ipv6.ErrInvalidAddress},
+		{"prefix too long", netip.MustParseAddr("2001:db8::"), 129, ipv6.ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ipv6.FromAddr(tt.addr, tt.prefix)
+			if !errors.Is(err, tt.wantError) {
+				t.Errorf("FromAddr() error = %v, want %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestIsValidCIDR(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want bool
+	}{
+		{"2001:db8::/32", true},
+		{"2001:db8::1/64", true},
+		{"::/0", true},
+		{"::ffff:192.0.2.1/128", true},
+		{"fe80::1%eth0/64", true},
+		{"fe80::1%/64", false},
+		{"2001:db8::/129", false},
+		{"2001:db8::g/64", false},
+		{"2001:db8::1", false},
+		{"192.168.0.0/24", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := ipv6.IsValidCIDR(tt.cidr); got != tt.want {
+			t.Errorf("IsValidCIDR(%q) = %v, want %v", tt.cidr, got, tt.want)
+		}
+
+		if _, err := ipv6.ParseCIDR(tt.cidr); (err == nil) != tt.want {
+			t.Errorf("ParseCIDR(%q) error = %v, disagrees with IsValidCIDR", tt.cidr, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package ipv6
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ipv4MappedRange holds the IPv4-mapped addresses (RFC 4291)
+var ipv4MappedRange = mustParseCIDR("::ffff:0:0/96")
+
+// ipv4EmbeddedRanges lists the prefixes whose last 32 bits carry an IPv4 address
+var ipv4EmbeddedRanges = []*net.IPNet{
+	ipv4MappedRange,
+	mustParseCIDR("64:ff9b::/96"),    // IPv4/IPv6 translation (RFC 6052)
+	mustParseCIDR("::ffff:0:0:0/96"), // IPv4-translated (RFC 2765)
+}
+
+// isIPv4Embedded reports whether the last 32 bits of the address carry an IPv4 address
+func isIPv4Embedded(ip net.IP) bool {
+	ip = ip.To16()
+	if ip == nil {
+		return false
+	}
+
+	for _, r := range ipv4EmbeddedRanges {
+		// Compare raw bytes, as net.IPNet.Contains treats IPv4-mapped addresses as IPv4
+		if ip.Mask(r.Mask).Equal(r.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mappedIPv4 returns the IPv4 address carried in an IPv4-mapped address (::ffff:0:0/96, RFC 4291),
+// and false for any other address
+func mappedIPv4(ip net.IP) (net.IP, bool) {
+	ip = ip.To16()
+	if ip == nil || !ip.Mask(ipv4MappedRange.Mask).Equal(ipv4MappedRange.IP) {
+		return nil, false
+	}
+
+	return ip.To4(), true
+}
+
+// FormatHextets renders an IPv6 address entirely in compressed hextets (RFC 5952), including
+// IPv4-embedded addresses, e.g. "::ffff:c0a8:101"
+func FormatHextets(ip net.IP) string {
+	ip = ip.To16()
+	if ip == nil {
+		return ""
+	}
+
+	return compressHextets(hextets(ip, 8))
+}
+
+// FormatMixed renders an IPv6 address in mixed notation (RFC 4291), with the last 32 bits as a
+// dotted quad, e.g. "::ffff:192.168.1.1"
+func FormatMixed(ip net.IP) string {
+	ip = ip.To16()
+	if ip == nil {
+		return ""
+	}
+
+	head := compressHextets(hextets(ip, 6))
+	tail := net.IP(ip[12:16]).String()
+
+	if strings.HasSuffix(head, "::") {
+		return head + tail
+	}
+
+	return head + ":" + tail
+}
+
+// hextets returns the first count 16-bit groups of a 16-byte address
+func hextets(ip net.IP, count int) []uint16 {
+	groups := make([]uint16, count)
+	for i := range groups {
+		groups[i] = uint16(ip[2*i])<<8 | uint16(ip[2*i+1])
+	}
+
+	return groups
+}
+
+// compressHextets joins hextets with colons, replacing the longest run of two or more zero groups
+// (the first one on ties) with "::" as recommended by RFC 5952
+func compressHextets(groups []uint16) string {
+	bestStart, bestLen := -1, 1
+
+	for i := 0; i < len(groups); {
+		if groups[i] != 0 {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(groups) && groups[j] == 0 {
+			j++
+		}
+
+		if j-i > bestLen {
+			bestStart, bestLen = i, j-i
+		}
+
+		i = j
+	}
+
+	format := func(groups []uint16) string {
+		parts := make([]string, len(groups))
+		for i, g := range groups {
+			parts[i] = fmt.Sprintf("%x", g)
+		}
+
+		return strings.Join(parts, ":")
+	}
+
+	if bestStart < 0 {
+		return format(groups)
+	}
+
+	return format(groups[:bestStart]) + "::" + format(groups[bestStart+bestLen:])
+}
+
+// StripLeadingZeros removes the leading zeros from a single hextet as RFC 5952 requires, e.g.
+// "0db8" becomes "db8" and "0000" becomes "0"
+func StripLeadingZeros(hextet string) string {
+	if stripped := strings.TrimLeft(hextet, "0"); stripped != "" {
+		return stripped
+	}
+
+	if hextet == "" {
+		return ""
+	}
+
+	return "0"
+}
+
+// NormalizeAddress rewrites an IPv6 address in the canonical text form of RFC 5952: lower case,
+// no leading zeros and the longest run of zero hextets compressed, with IPv4-mapped addresses in
+// mixed notation. It returns ErrInvalidAddress for anything that isn't an IPv6 address.
+func NormalizeAddress(s string) (string, error) {
+	ip := net.ParseIP(s)
+	if ip == nil || !strings.Contains(s, ":") {
+		return "", fmt.Errorf("%w: %q is not an IPv6 address", ErrInvalidAddress, s)
+	}
+
+	if ip.To4() != nil {
+		return FormatMixed(ip), nil
+	}
+
+	return ip.String(), nil
+}
+
+// URLForm returns the address bracketed for use in a URL or connection string (RFC 3986), e.g.
+// "[2001:db8::1]:443", or just "[2001:db8::1]" if port isn't positive. A zone is appended with
+// its % escaped as %25 (RFC 6874).
+func (n *Network) URLForm(port int) string {
+	host := n.formatAddress(n.Address)
+	if n.Zone != "" {
+		host += "%25" + n.Zone
+	}
+
+	if port <= 0 {
+		return "[" + host + "]"
+	}
+
+	return fmt.Sprintf("[%s]:%d", host, port)
+}
@@ -0,0 +1,186 @@
+package ipv6_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestFormatMixedAndHextets(t *testing.T) {
+	tests := []struct {
+		name            string
+		address         string
+		expectedMixed   string
+		expectedHextets string
+	}{
+		{
+			name:            "IPv4-mapped",
+			address:         "::ffff:192.168.1.1",
+			expectedMixed:   "::ffff:192.168.1.1",
+			expectedHextets: "::ffff:c0a8:101",
+		},
+		{
+			name:            "NAT64 well-known prefix",
+			address:         "64:ff9b::c000:201",
+			expectedMixed:   "64:ff9b::192.0.2.1",
+			expectedHextets: "64:ff9b::c000:201",
+		},
+		{
+			name:            "no zero run",
+			address:         "2001:db8:1:2:3:4:5:6",
+			expectedMixed:   "2001:db8:1:2:3:4:0.5.0.6",
+			expectedHextets: "2001:db8:1:2:3:4:5:6",
+		},
+		{
+			name:            "single zero group is not compressed",
+			address:         "2001:db8:0:1:1:1:1:1",
+			expectedMixed:   "2001:db8:0:1:1:1:0.1.0.1",
+			expectedHextets: "2001:db8:0:1:1:1:1:1",
+		},
+		{
+			name:            "first of equal zero runs wins",
+			address:         "2001:db8:0:0:1:0:0:1",
+			expectedMixed:   "2001:db8::1:0:0.0.0.1",
+			expectedHextets: "2001:db8::1:0:0:1",
+		},
+		{
+			name:            "longest zero run wins",
+			address:         "2001:0:0:1:0:0:0:1",
+			expectedMixed:   "2001::1:0:0:0.0.0.1",
+			expectedHextets: "2001:0:0:1::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := net.ParseIP(tt.address)
+			if ip == nil {
+				t.Fatalf("Failed to parse IP: %s", tt.address)
+			}
+
+			if result := ipv6.FormatMixed(ip); result != tt.expectedMixed {
+				t.Errorf("FormatMixed() = %q, expected %q", result, tt.expectedMixed)
+			}
+
+			if result := ipv6.FormatHextets(ip); result != tt.expectedHextets {
+				t.Errorf("FormatHextets() = %q, expected %q", result, tt.expectedHextets)
+			}
+		})
+	}
+}
+
+func TestFormattedTextMixed(t *testing.T) {
+	network, err := ipv6.ParseCIDR("64:ff9b::c000:201/128")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	if output := network.FormattedText(); !containsString(output, "64:ff9b::c000:201") {
+		t.Errorf("FormattedText() missing hextet address:\n%s", output)
+	}
+
+	network.Format.Mixed = true
+
+	if output := network.FormattedText(); !containsString(output, "64:ff9b::192.0.2.1") {
+		t.Errorf("FormattedText() missing mixed notation address:\n%s", output)
+	}
+}
+
+func TestStripLeadingZeros(t *testing.T) {
+	tests := []struct {
+		hextet string
+		want   string
+	}{
+		{"0db8", "db8"},
+		{"0000", "0"},
+		{"0", "0"},
+		{"2001", "2001"},
+		{"00a0", "a0"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hextet, func(t *testing.T) {
+			if got := ipv6.StripLeadingZeros(tt.hextet); got != tt.want {
+				t.Errorf("StripLeadingZeros(%q) = %q, want %q", tt.hextet, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"2001:0DB8:0000:0000:0000:0000:0000:0001", "2001:db8::1", false},
+		{"2001:db8:0:0:1:0:0:1", "2001:db8::1:0:0:1", false},
+		{"0000:0000:0000:0000:0000:ffff:c0a8:0101", "::ffff:192.168.1.1", false},
+		{"::", "::", false},
+		{"192.168.1.1", "", true},
+		{"2001:db8::g", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ipv6.NormalizeAddress(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("NormalizeAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormattedTextWithMaskNoBinary_WildcardNotDottedQuad(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::/80")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if output := network.FormattedTextWithMaskNoBinary(); !strings.Contains(output, "Wildcard:\t::ffff:ffff:ffff\n") {
+		t.Errorf("Wildcard should render as hextets\nFull output:\n%s", output)
+	}
+}
+
+func TestNetwork_URLForm(t *testing.T) {
+	tests := []struct {
+		cidr string
+		port int
+		want string
+	}{
+		{"2001:db8::1/64", 443, "[2001:db8::1]:443"},
+		{"2001:db8::1/64", 0, "[2001:db8::1]"},
+		{"2001:db8::1/128", -1, "[2001:db8::1]"},
+		{"fe80::1%eth0/64", 8080, "[fe80::1%25eth0]:8080"},
+		{"::ffff:192.0.2.1/128", 80, "[::ffff:c000:201]:80"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.URLForm(tt.port); got != tt.want {
+				t.Errorf("URLForm(%d) = %q, want %q", tt.port, got, tt.want)
+			}
+		})
+	}
+}
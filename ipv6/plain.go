@@ -0,0 +1,9 @@
+package ipv6
+
+import "github.com/ronny/ripcalc/internal/layout"
+
+// PlainText returns every field of the no-binary output including the netmask and wildcard,
+// aligned with spaces instead of tabs so it pastes cleanly into chat and email
+func (n *Network) PlainText() string {
+	return layout.Plain(n.FormattedTextWithMaskNoBinary())
+}
@@ -0,0 +1,41 @@
+package ipv6_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestPlainText(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	output := network.PlainText()
+
+	if strings.Contains(output, "\t") {
+		t.Errorf("PlainText() contains tabs:\n%s", output)
+	}
+
+	if regexp.MustCompile(`[01]{8}`).MatchString(output) {
+		t.Errorf("PlainText() contains binary:\n%s", output)
+	}
+
+	for _, line := range []string{"   Address: 2001:db8::", "   Netmask: ffff:ffff:ffff:ffff::", "Host count: 2^64"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("PlainText() missing %q\n%s", line, output)
+		}
+	}
+
+	separator, longest := separatorAndLongestRow(output)
+	if separator != longest {
+		t.Errorf("separator length = %d, longest row = %d\n%s", separator, longest, output)
+	}
+}
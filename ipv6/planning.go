@@ -0,0 +1,133 @@
+package ipv6
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+)
+
+// Prefix lengths IPv6 planning advice is based on
+const (
+	sitePrefixLength         = 48
+	lanPrefixLength          = 64
+	pointToPointPrefixLength = 127
+)
+
+// SupportsSLAAC reports whether hosts on a link using the network can autoconfigure addresses with
+// SLAAC (RFC 4862), which only forms 64-bit interface identifiers and so only works on a /64
+func (n *Network) SupportsSLAAC() bool {
+	return n.PrefixLength == lanPrefixLength
+}
+
+// PlanningNotes returns best-practice advice for using the prefix length on a link: /64 for LANs
+// so SLAAC works, /127 for point-to-point links (RFC 6164), and shorter prefixes split into /64s
+func (n *Network) PlanningNotes() []string {
+	switch p := n.PrefixLength; {
+	case p < lanPrefixLength:
+		lans := new(big.Int).Lsh(big.NewInt(1), uint(lanPrefixLength-p))
+		return []string{fmt.Sprintf("/%d is too large for a single link; split it into %s /64 LANs", p, lans)}
+	case n.SupportsSLAAC():
+		return []string{"/64 is the standard LAN size and supports SLAAC"}
+	case p < pointToPointPrefixLength:
+		return []string{
+			fmt.Sprintf("/%d on a LAN will break SLAAC; use /64", p),
+			fmt.Sprintf("/%d is shorter than recommended for a point-to-point link; use /127 (RFC 6164)", p),
+		}
+	case p == pointToPointPrefixLength:
+		return []string{"/127 suits point-to-point links (RFC 6164) but will break SLAAC on a LAN"}
+	default:
+		return []string{"/128 is a single host route, e.g. a loopback, not a link"}
+	}
+}
+
+// SiteAndSubnet returns the calculated /48 site and /64 subnet the network falls within, showing
+// where e.g. a /96 sits in an addressing plan. Either is nil if the prefix isn't longer than it.
+func (n *Network) SiteAndSubnet() (site *Network, subnet *Network) {
+	return n.enclosing(sitePrefixLength), n.enclosing(lanPrefixLength)
+}
+
+// enclosing returns the calculated network of the given shorter prefix length containing n, with
+// n's format options, or nil if n's prefix isn't longer or it has no address
+func (n *Network) enclosing(prefixLength int) *Network {
+	if n.PrefixLength <= prefixLength || n.Address == nil {
+		return nil
+	}
+
+	parent := &Network{
+		Address:      n.Address.Mask(net.CIDRMask(prefixLength, 128)),
+		PrefixLength: prefixLength,
+		Format:       n.Format,
+		CustomRanges: n.CustomRanges,
+	}
+
+	if err := parent.Calculate(); err != nil {
+		return nil
+	}
+
+	return parent
+}
+
+// contextRows returns the Site and Subnet lines placing the network in an addressing plan, or an
+// empty string if its prefix is /48 or shorter. Like the Scope row, they're left out when the
+// NoClass format option is set, and for the unspecified, loopback and IPv4-mapped addresses, which
+// aren't part of any addressing plan.
+func (n *Network) contextRows() string {
+	if n.Format.NoClass {
+		return ""
+	}
+
+	switch classifyAddressType(n.Address) {
+	case addressTypeUnspecified, addressTypeLoopback, addressTypeIPv4Mapped:
+		return ""
+	}
+
+	site, subnet := n.SiteAndSubnet()
+
+	var b strings.Builder
+
+	for _, row := range []struct {
+		label   string
+		network *Network
+	}{
+		{"      Site", site},
+		{"    Subnet", subnet},
+	} {
+		if row.network != nil {
+			fmt.Fprintf(&b, "\n%s:\t%s/%d", row.label, n.formatAddress(row.network.Network), row.network.PrefixLength)
+		}
+	}
+
+	return b.String()
+}
+
+// List64s returns the first limit /64 LANs within the network in ascending order, calculated with
+// the network's format options, or all of them if there are fewer. A /64 lists itself. It returns
+// ErrInvalidPrefix for prefixes longer than /64, which hold no complete LAN.
+func (n *Network) List64s(limit int) ([]*Network, error) {
+	if n.PrefixLength > lanPrefixLength {
+		return nil, fmt.Errorf("%w: /%d is longer than /%d", ErrInvalidPrefix, n.PrefixLength, lanPrefixLength)
+	}
+
+	first, last := n.bounds()
+	step := new(big.Int).Lsh(big.NewInt(1), 128-lanPrefixLength)
+
+	var lans []*Network
+
+	for addr := first; len(lans) < limit && addr.Cmp(last) <= 0; addr = new(big.Int).Add(addr, step) {
+		lan := &Network{
+			Address:      fromBigInt(addr),
+			PrefixLength: lanPrefixLength,
+			Format:       n.Format,
+			CustomRanges: n.CustomRanges,
+		}
+
+		if err := lan.Calculate(); err != nil {
+			return nil, fmt.Errorf("lan.Calculate: %w", err)
+		}
+
+		lans = append(lans, lan)
+	}
+
+	return lans, nil
+}
@@ -0,0 +1,233 @@
+package ipv6_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestNetwork_PlanningNotes(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want []string
+	}{
+		{"2001:db8::/120", []string{
+			"/120 on a LAN will break SLAAC; use /64",
+			"/120 is shorter than recommended for a point-to-point link; use /127 (RFC 6164)",
+		}},
+		{"2001:db8::/64", []string{"/64 is the standard LAN size and supports SLAAC"}},
+		{"2001:db8::/56", []string{"/56 is too large for a single link; split it into 256 /64 LANs"}},
+		{"2001:db8::/127", []string{"/127 suits point-to-point links (RFC 6164) but will break SLAAC on a LAN"}},
+		{"2001:db8::1/128", []string{"/128 is a single host route, e.g. a loopback, not a link"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.PlanningNotes(); !slices.Equal(got, tt.want) {
+				t.Errorf("PlanningNotes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_List64s(t *testing.T) {
+	tests := []struct {
+		name  string
+		cidr  string
+		limit int
+		want  []string
+	}{
+		{"all four of a /62", "2001:db8::/62", 16, []string{
+			"2001:db8::/64", "2001:db8:0:1::/64", "2001:db8:0:2::/64", "2001:db8:0:3::/64",
+		}},
+		{"limited /56", "2001:db8:0:ab00::/56", 2, []string{"2001:db8:0:ab00::/64", "2001:db8:0:ab01::/64"}},
+		{"host bits ignored", "2001:db8::1/63", 16, []string{"2001:db8::/64", "2001:db8:0:1::/64"}},
+		{"a /64 lists itself", "2001:db8::/64", 16, []string{"2001:db8::/64"}},
+		{"zero limit", "2001:db8::/62", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			lans, err := network.List64s(tt.limit)
+			if err != nil {
+				t.Fatalf("List64s() error = %v", err)
+			}
+
+			var got []string
+			for _, lan := range lans {
+				got = append(got, lan.String())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("List64s() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_List64s_TooLong(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::/80")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if _, err := network.List64s(16); !errors.Is(err, ipv6.ErrInvalidPrefix) {
+		t.Errorf("List64s() error = %v, want %v", err, ipv6.ErrInvalidPrefix)
+	}
+}
+
+func TestNetwork_SupportsSLAAC(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want bool
+	}{
+		{"2001:db8::/64", true},
+		{"2001:db8::/80", false},
+		{"2001:db8::/48", false},
+		{"2001:db8::1/128", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.SupportsSLAAC(); got != tt.want {
+				t.Errorf("SupportsSLAAC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetwork_FormattedTextLinkLocalSLAACNote(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		wantNote bool
+	}{
+		{"fe80::/80", true},
+		{"fe80::/64", false},
+		{"fe80::1/128", false},
+		{"2001:db8::/80", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			hasNote := strings.Contains(network.FormattedText(), "breaks SLAAC")
+			if hasNote != tt.wantNote {
+				t.Errorf("SLAAC note present = %v, want %v\n%s", hasNote, tt.wantNote, network.FormattedText())
+			}
+		})
+	}
+}
+
+func TestNetwork_SiteAndSubnet(t *testing.T) {
+	tests := []struct {
+		cidr       string
+		wantSite   string
+		wantSubnet string
+	}{
+		{"2001:db8:1:2:3:4::/96", "2001:db8:1::/48", "2001:db8:1:2::/64"},
+		{"2001:db8:1:2::1/128", "2001:db8:1::/48", "2001:db8:1:2::/64"},
+		{"2001:db8:1:200::/56", "2001:db8:1::/48", ""},
+		{"2001:db8:1:2::/64", "2001:db8:1::/48", ""},
+		{"2001:db8:1::/48", "", ""},
+		{"2001:db8::/32", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			site, subnet := network.SiteAndSubnet()
+
+			if got := cidrOrEmpty(site); got != tt.wantSite {
+				t.Errorf("site = %q, want %q", got, tt.wantSite)
+			}
+
+			if got := cidrOrEmpty(subnet); got != tt.wantSubnet {
+				t.Errorf("subnet = %q, want %q", got, tt.wantSubnet)
+			}
+
+			output := network.FormattedText()
+
+			if tt.wantSite != "" && !strings.Contains(output, "      Site:\t"+tt.wantSite) {
+				t.Errorf("FormattedText() missing the Site row:\n%s", output)
+			}
+
+			if tt.wantSubnet != "" && !strings.Contains(output, "    Subnet:\t"+tt.wantSubnet) {
+				t.Errorf("FormattedText() missing the Subnet row:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestFormattedText_ContextRowsSkipped(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		noClass bool
+	}{
+		{"::/128", false},
+		{"::1/128", false},
+		{"::ffff:192.168.1.1/128", false},
+		{"2001:db8:1:2::1/128", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.Format.NoClass = tt.noClass
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if output := network.FormattedText(); strings.Contains(output, "Site:") || strings.Contains(output, "Subnet:") {
+				t.Errorf("FormattedText() has a Site or Subnet row:\n%s", output)
+			}
+		})
+	}
+}
+
+// cidrOrEmpty returns the network's CIDR, or an empty string for nil
+func cidrOrEmpty(n *ipv6.Network) string {
+	if n == nil {
+		return ""
+	}
+
+	return n.String()
+}
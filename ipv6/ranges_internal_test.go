@@ -0,0 +1,83 @@
+package ipv6
+
+import (
+	"net"
+	"testing"
+)
+
+// rangeBounds returns the first and last addresses of network as 16-byte addresses
+func rangeBounds(network *net.IPNet) (net.IP, net.IP) {
+	first := network.IP.To16()
+	last := make(net.IP, net.IPv6len)
+
+	for i := range last {
+		last[i] = first[i] | ^network.Mask[i]
+	}
+
+	return first, last
+}
+
+// longestMatch returns the index of the longest network containing ip, or -1 if none does. It
+// compares raw bytes, as net.IPNet.Contains treats IPv4-mapped addresses as IPv4.
+func longestMatch(networks []*net.IPNet, ip net.IP) int {
+	best, bestLen := -1, -1
+
+	for i, network := range networks {
+		ones, _ := network.Mask.Size()
+		if ones > bestLen && ip.Mask(network.Mask).Equal(network.IP) {
+			best, bestLen = i, ones
+		}
+	}
+
+	return best
+}
+
+func TestSpecialRangeTrie_MatchesLinearLongestMatch(t *testing.T) {
+	networks := make([]*net.IPNet, 0, len(specialRanges))
+	for _, r := range specialRanges {
+		networks = append(networks, r.network)
+	}
+
+	for _, r := range specialRanges {
+		first, last := rangeBounds(r.network)
+
+		for _, ip := range []net.IP{first, last} {
+			t.Run(compressIPv6(ip), func(t *testing.T) {
+				want := longestMatch(networks, ip)
+				if want < 0 {
+					t.Fatalf("no range contains %s", ip)
+				}
+
+				got, ok := specialRangeTrie.Lookup(ip)
+				if !ok || got.network.String() != networks[want].String() {
+					t.Errorf("Lookup(%s) = %s, %t, want %s", ip, got.network, ok, networks[want])
+				}
+			})
+		}
+	}
+}
+
+func TestRIRRangeTrie_MatchesLinearLongestMatch(t *testing.T) {
+	networks := make([]*net.IPNet, 0, len(rirRanges))
+	for _, r := range rirRanges {
+		networks = append(networks, r.network)
+	}
+
+	for _, r := range rirRanges {
+		first, last := rangeBounds(r.network)
+
+		for _, ip := range []net.IP{first, last} {
+			t.Run(compressIPv6(ip), func(t *testing.T) {
+				want := longestMatch(networks, ip)
+				if want < 0 {
+					t.Fatalf("no range contains %s", ip)
+				}
+
+				got, ok := rirRangeTrie.Lookup(ip)
+				if !ok || got != rirRanges[want].rir {
+					t.Errorf("Lookup(%s) = %q, %t, want %q", ip, got, ok, rirRanges[want].rir)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package ipv6
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReferenceTable returns a prefix cheat-sheet covering every prefix length from /0 to /128 with its
+// netmask, wildcard, address count, and the number of /64 subnets it contains, using powers of two
+// for counts
+func ReferenceTable() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%-6s  %-39s  %-39s  %-9s  %s\n", "Prefix", "Netmask", "Wildcard", "Addresses", "/64 subnets")
+
+	for prefixLen := 0; prefixLen <= 128; prefixLen++ {
+		subnets := "-"
+		if prefixLen <= 64 {
+			subnets = powerOfTwo(64 - prefixLen)
+		}
+
+		fmt.Fprintf(&b, "%-6s  %-39s  %-39s  %-9s  %s\n",
+			fmt.Sprintf("/%d", prefixLen),
+			compressIPv6(calculateIPv6Netmask(prefixLen)),
+			compressIPv6(calculateIPv6Wildcard(prefixLen)),
+			powerOfTwo(128-prefixLen),
+			subnets,
+		)
+	}
+
+	return b.String()
+}
+
+// powerOfTwo renders 2^exp, using "1" for 2^0
+func powerOfTwo(exp int) string {
+	if exp == 0 {
+		return "1"
+	}
+
+	return fmt.Sprintf("2^%d", exp)
+}
@@ -0,0 +1,55 @@
+package ipv6_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestReferenceTable(t *testing.T) {
+	table := ipv6.ReferenceTable()
+
+	lines := strings.Split(strings.TrimSuffix(table, "\n"), "\n")
+	if len(lines) != 130 {
+		t.Fatalf("ReferenceTable() lines = %d, expected 130 (header plus /0 to /128)", len(lines))
+	}
+
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{
+			prefix:   "/48",
+			expected: "/48 ffff:ffff:ffff:: ::ffff:ffff:ffff:ffff:ffff 2^80 2^16",
+		},
+		{
+			prefix:   "/64",
+			expected: "/64 ffff:ffff:ffff:ffff:: ::ffff:ffff:ffff:ffff 2^64 1",
+		},
+		{
+			prefix:   "/96",
+			expected: "/96 ffff:ffff:ffff:ffff:ffff:ffff:: ::ffff:ffff 2^32 -",
+		},
+		{
+			prefix:   "/128",
+			expected: "/128 ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff :: 1 -",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			var row string
+
+			for _, line := range lines {
+				if strings.HasPrefix(line, tt.prefix+" ") {
+					row = strings.Join(strings.Fields(line), " ")
+				}
+			}
+
+			if row != tt.expected {
+				t.Errorf("ReferenceTable() row = %q, expected %q", row, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package ipv6
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// IsProperSubnet reports whether child is a correctly carved subnet of n: a longer prefix,
+// aligned on its own boundary (no host bits set) and entirely inside n
+func (n *Network) IsProperSubnet(child *Network) bool {
+	if child.PrefixLength <= n.PrefixLength {
+		return false
+	}
+
+	first, last := n.bounds()
+	childFirst, childLast := child.bounds()
+
+	return childFirst.Cmp(toBigInt(child.Address)) == 0 && childFirst.Cmp(first) >= 0 && childLast.Cmp(last) <= 0
+}
+
+// Distance returns the signed number of addresses from a's base address to b's, negative when b
+// comes before a, to quantify the gap between allocations
+func Distance(a, b *Network) *big.Int {
+	aFirst, _ := a.bounds()
+	bFirst, _ := b.bounds()
+
+	return bFirst.Sub(bFirst, aFirst)
+}
+
+// Compare orders networks by base address and then by prefix length, shorter first so a supernet
+// sorts before its subnets, returning -1, 0 or +1 for use with slices.SortFunc
+func Compare(a, b *Network) int {
+	aFirst, _ := a.bounds()
+	bFirst, _ := b.bounds()
+
+	if c := aFirst.Cmp(bFirst); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.PrefixLength, b.PrefixLength)
+}
+
+// Contains reports whether ip is one of the network's addresses
+func (n *Network) Contains(ip net.IP) bool {
+	if ip.To16() == nil {
+		return false
+	}
+
+	first, last := n.bounds()
+	addr := toBigInt(ip)
+
+	return addr.Cmp(first) >= 0 && addr.Cmp(last) <= 0
+}
+
+// CoveringPrefixes returns calculated networks containing ip at every step-th prefix length from /0,
+// always ending with the /128, e.g. the nibble boundaries /0, /4 ... /128 for a step of 4, since
+// all 129 lengths make for an unwieldy chain. It returns ErrInvalidPrefix for a step below 1 and
+// ErrInvalidAddress if ip isn't an IPv6 address.
+func CoveringPrefixes(ip net.IP, step int) ([]*Network, error) {
+	if step < 1 {
+		return nil, fmt.Errorf("%w: step %d is below 1", ErrInvalidPrefix, step)
+	}
+
+	if ip.To16() == nil {
+		return nil, fmt.Errorf("%w: %s is not an IPv6 address", ErrInvalidAddress, ip)
+	}
+
+	var networks []*Network
+
+	for prefix := 0; ; prefix = min(prefix+step, 128) {
+		first, _ := (&Network{Address: ip.To16(), PrefixLength: prefix}).bounds()
+
+		network := &Network{Address: fromBigInt(first), PrefixLength: prefix}
+		if err := network.Calculate(); err != nil {
+			return nil, fmt.Errorf("network.Calculate: %w", err)
+		}
+
+		networks = append(networks, network)
+
+		if prefix == 128 {
+			return networks, nil
+		}
+	}
+}
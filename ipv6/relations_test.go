@@ -0,0 +1,170 @@
+package ipv6_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestIsProperSubnet(t *testing.T) {
+	tests := []struct {
+		name   string
+		parent string
+		child  string
+		want   bool
+	}{
+		{"aligned child", "2001:db8::/32", "2001:db8:1::/48", true},
+		{"aligned host route", "2001:db8::/64", "2001:db8::ffff/128", true},
+		{"misaligned child", "2001:db8::/32", "2001:db8:1::1/48", false},
+		{"outside parent", "2001:db8::/32", "2001:db9::/48", false},
+		{"same prefix", "2001:db8::/32", "2001:db8::/32", false},
+		{"shorter prefix", "2001:db8::/32", "2001::/16", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := ipv6.ParseCIDR(tt.parent)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			child, err := ipv6.ParseCIDR(tt.child)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := parent.IsProperSubnet(child); got != tt.want {
+				t.Errorf("IsProperSubnet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{"forward", "2001:db8::/64", "2001:db8:0:1::/64", "18446744073709551616"},
+		{"backward", "2001:db8:0:1::/64", "2001:db8::/64", "-18446744073709551616"},
+		{"identical", "2001:db8::/64", "2001:db8::/64", "0"},
+		{"whole space", "::/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128", "340282366920938463463374607431768211455"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ipv6.ParseCIDR(tt.a)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			b, err := ipv6.ParseCIDR(tt.b)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := ipv6.Distance(a, b); got.String() != tt.want {
+				t.Errorf("Distance() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"lower address first", "2001:db8::/64", "2001:db8:0:1::/64", -1},
+		{"higher address last", "fe80::/10", "2001:db8::/32", 1},
+		{"supernet before subnet", "2001:db8::/32", "2001:db8::/48", -1},
+		{"host bits ignored", "2001:db8::1/64", "2001:db8::/64", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ipv6.ParseCIDR(tt.a)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			b, err := ipv6.ParseCIDR(tt.b)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := ipv6.Compare(a, b); got != tt.want {
+				t.Errorf("Compare() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		cidr string
+		ip   string
+		want bool
+	}{
+		{"2001:db8::/32", "2001:db8:ffff::1", true},
+		{"2001:db8::/32", "2001:db9::", false},
+		{"2001:db8::/128", "2001:db8::", true},
+		{"::/0", "ffff::1", true},
+		{"2001:db8::/32", "not an address", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr+" "+tt.ip, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.Contains(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoveringPrefixes(t *testing.T) {
+	tests := []struct {
+		step       int
+		wantCount  int
+		wantPrefix map[int]string
+	}{
+		{4, 33, map[int]string{0: "::/0", 4: "2001::/16", 8: "2001:db8::/32", 16: "2001:db8::/64", 32: "2001:db8::1/128"}},
+		{48, 4, map[int]string{1: "2001:db8::/48", 2: "2001:db8::/96", 3: "2001:db8::1/128"}},
+		{1, 129, map[int]string{64: "2001:db8::/64"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("step %d", tt.step), func(t *testing.T) {
+			networks, err := ipv6.CoveringPrefixes(net.ParseIP("2001:db8::1"), tt.step)
+			if err != nil {
+				t.Fatalf("CoveringPrefixes() error = %v", err)
+			}
+
+			if len(networks) != tt.wantCount {
+				t.Fatalf("CoveringPrefixes() returned %d networks, want %d", len(networks), tt.wantCount)
+			}
+
+			for i, want := range tt.wantPrefix {
+				if got := networks[i].String(); got != want {
+					t.Errorf("CoveringPrefixes()[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+
+	if _, err := ipv6.CoveringPrefixes(net.ParseIP("2001:db8::1"), 0); !errors.Is(err, ipv6.ErrInvalidPrefix) {
+		t.Errorf("CoveringPrefixes(step 0) error = %v, want ErrInvalidPrefix", err)
+	}
+}
@@ -0,0 +1,86 @@
+package ipv6
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ronny/ripcalc/internal/zonefile"
+)
+
+// Bounds on the networks ZoneFileTemplate renders: /64 LANs and their subsets are accepted, PTR
+// records are written for at most the first maxZoneRecords addresses, and the $ORIGIN is the
+// network's nibble-aligned zone, no longer than /120 so every owner name has at least two nibbles
+const (
+	minZonePrefixLength = lanPrefixLength
+	maxZoneRecords      = 256
+	maxZoneOrigin       = 120
+)
+
+// ZoneFileTemplate returns a BIND-style ip6.arpa reverse zone file for the network with a
+// placeholder SOA, an NS record, and one PTR record per address mapping to host-N.domain, where N
+// is the address's offset from the network address. The $ORIGIN is the nibble-aligned zone
+// containing the network, as NibbleAlignedZone gives, up to /120. Networks larger than 256
+// addresses only list the first 256, after a comment saying so. It returns an empty string for
+// networks shorter than /64.
+func (n *Network) ZoneFileTemplate(domain string) string {
+	if n.PrefixLength < minZonePrefixLength {
+		return ""
+	}
+
+	domain = strings.TrimSuffix(domain, ".") + "."
+	originNibbles := min(n.PrefixLength, maxZoneOrigin) / 4
+	nibbles := reversedNibbles(n.Network.To16())
+
+	var b strings.Builder
+
+	b.WriteString(zonefile.Header(strings.Join(nibbles[32-originNibbles:], ".")+".ip6.arpa.", domain))
+
+	count := int64(maxZoneRecords)
+	if total := calculateHostCount(n.PrefixLength); total.IsInt64() && total.Int64() <= count {
+		count = total.Int64()
+	} else {
+		fmt.Fprintf(&b, "; only the first %d of %s addresses are listed\n", count, powerOfTwo(128-n.PrefixLength))
+	}
+
+	first := toBigInt(n.Network)
+	addr := new(big.Int)
+
+	for offset := range count {
+		addr.Add(first, big.NewInt(offset))
+		owner := reversedNibbles(fromBigInt(addr))[:32-originNibbles]
+		fmt.Fprintf(&b, "%s\tIN\tPTR\thost-%d.%s\n", strings.Join(owner, "."), offset, domain)
+	}
+
+	return b.String()
+}
+
+// NibbleAlignedZone returns the ip6.arpa zone to delegate for the network and the prefix length it
+// covers. Reverse delegation happens on nibble (4-bit) boundaries, so the zone is taken at the
+// nearest boundary at or above the network, e.g. a /58 is delegated from its /56 zone.
+func (n *Network) NibbleAlignedZone() (zone string, delegationPrefix int) {
+	delegationPrefix = n.PrefixLength / 4 * 4
+
+	first, _ := n.bounds()
+	nibbles := reversedNibbles(fromBigInt(first))[32-delegationPrefix/4:]
+
+	return strings.Join(append(nibbles, "ip6.arpa."), "."), delegationPrefix
+}
+
+// reversedNibbles returns the 32 hex nibbles of an IPv6 address, least significant first, as
+// used to build ip6.arpa names
+func reversedNibbles(ip []byte) []string {
+	nibbles := make([]string, 0, 32)
+
+	for i := len(ip) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, fmt.Sprintf("%x", ip[i]&0x0f), fmt.Sprintf("%x", ip[i]>>4))
+	}
+
+	return nibbles
+}
+
+// ForwardRecord returns a forward DNS AAAA record for the address under name, the counterpart of
+// the PTR records in ZoneFileTemplate
+func (n *Network) ForwardRecord(name string) string {
+	return fmt.Sprintf("%s. IN AAAA %s", strings.TrimSuffix(name, "."), compressIPv6(n.Address))
+}
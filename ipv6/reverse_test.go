@@ -0,0 +1,143 @@
+package ipv6_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestZoneFileTemplate(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::10/124")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	zone := network.ZoneFileTemplate("example.com.")
+
+	expectedLines := []string{
+		"$ORIGIN 0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		"@\tIN\tNS\tns1.example.com.",
+		"0.1\tIN\tPTR\thost-0.example.com.",
+		"f.1\tIN\tPTR\thost-15.example.com.",
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(zone, line+"\n") {
+			t.Errorf("ZoneFileTemplate() missing line %q\n%s", line, zone)
+		}
+	}
+
+	if got := strings.Count(zone, "\tPTR\t"); got != 16 {
+		t.Errorf("ZoneFileTemplate() PTR records = %d, expected 16", got)
+	}
+}
+
+func TestZoneFileTemplateLAN(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8:0:1::/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	zone := network.ZoneFileTemplate("example.com")
+
+	expectedLines := []string{
+		"$ORIGIN 1.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.",
+		"; only the first 256 of 2^64 addresses are listed",
+		"0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0\tIN\tPTR\thost-0.example.com.",
+		"f.f.0.0.0.0.0.0.0.0.0.0.0.0.0.0\tIN\tPTR\thost-255.example.com.",
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(zone, line+"\n") {
+			t.Errorf("ZoneFileTemplate() missing line %q\n%s", line, zone)
+		}
+	}
+
+	if got := strings.Count(zone, "\tPTR\t"); got != 256 {
+		t.Errorf("ZoneFileTemplate() PTR records = %d, expected 256", got)
+	}
+}
+
+func TestZoneFileTemplateTooLarge(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2001:db8::/60")
+	if err != nil {
+		t.Fatalf("ParseCIDR() unexpected error: %v", err)
+	}
+
+	err = network.Calculate()
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	if zone := network.ZoneFileTemplate("example.com"); zone != "" {
+		t.Errorf("ZoneFileTemplate() = %q, expected empty for /60", zone)
+	}
+}
+
+func TestForwardRecord(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		name     string
+		expected string
+	}{
+		{"2001:db8::1/64", "www.example.com", "www.example.com. IN AAAA 2001:db8::1"},
+		{"2001:DB8:0:0::a/128", "host.example.com.", "host.example.com. IN AAAA 2001:db8::a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.ForwardRecord(tt.name); got != tt.expected {
+				t.Errorf("ForwardRecord() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNibbleAlignedZone(t *testing.T) {
+	tests := []struct {
+		cidr           string
+		wantZone       string
+		wantPrefix     int
+		wantNibbleSize int
+	}{
+		{"2001:db8:ab:cd00::/56", "d.c.b.a.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", 56, 14},
+		{"2001:db8:ab:cd40::/58", "d.c.b.a.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", 56, 14},
+		{"2001:db8::/32", "8.b.d.0.1.0.0.2.ip6.arpa.", 32, 8},
+		{"2001:db8::1/64", "0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", 64, 16},
+		{"::/0", "ip6.arpa.", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			zone, prefix := network.NibbleAlignedZone()
+			if zone != tt.wantZone || prefix != tt.wantPrefix {
+				t.Errorf("NibbleAlignedZone() = %q, %d, want %q, %d", zone, prefix, tt.wantZone, tt.wantPrefix)
+			}
+
+			if got := strings.Count(zone, ".") - 2; got != tt.wantNibbleSize {
+				t.Errorf("NibbleAlignedZone() has %d nibbles, want %d", got, tt.wantNibbleSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package ipv6
+
+import (
+	"net"
+
+	"github.com/ronny/ripcalc/ipv4"
+)
+
+// Scope returns the address's scope in the RFC 4007 vocabulary shared by unicast and multicast,
+// e.g. "Link-Local" for fe80::1 and ff02::1, or "Global" for 2001:db8::1. Multicast scopes are
+// decoded from the scope field; loopback is Interface-Local; unique local addresses are Global as
+// RFC 4193 defines them, even though they are only routed within a site. IPv4-mapped addresses
+// take the scope of the embedded IPv4 address from ipv4.AddressScope, e.g. "Private" for
+// ::ffff:192.168.1.1. It returns "Unspecified" for ::.
+func (n *Network) Scope() string {
+	return addressScope(n.Address)
+}
+
+// addressScope returns the scope of ip as described by Scope
+func addressScope(ip net.IP) string {
+	switch classifyAddressType(ip) {
+	case addressTypeMulticast:
+		return getMulticastScope(ip.To16())
+	case addressTypeLinkLocal:
+		return "Link-Local"
+	case addressTypeLoopback:
+		return "Interface-Local"
+	case addressTypeUnspecified:
+		return "Unspecified"
+	case addressTypeIPv4Mapped:
+		v4, _ := mappedIPv4(ip)
+		return ipv4.AddressScope(v4)
+	default:
+		if siteLocalRange.Contains(ip) {
+			return "Site-Local"
+		}
+
+		return "Global"
+	}
+}
+
+// siteLocalRange holds the deprecated site-local unicast addresses (RFC 3879), which still have
+// site scope
+var siteLocalRange = mustParseCIDR("fec0::/10")
@@ -0,0 +1,75 @@
+package ipv6_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestNetwork_Scope(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{"fe80::1/64", "Link-Local"},
+		{"2001:4860::8888/128", "Global"},
+		{"2001:db8::/32", "Global"},
+		{"fd00::1/64", "Global"},
+		{"fec0::1/64", "Site-Local"},
+		{"::1/128", "Interface-Local"},
+		{"::/128", "Unspecified"},
+		{"ff02::1/128", "Link-Local"},
+		{"ff05::2/128", "Site-Local"},
+		{"ff08::1/128", "Organization-Local"},
+		{"ff0e::1/128", "Global"},
+		{"::ffff:192.168.1.1/128", "Private"},
+		{"::ffff:8.8.8.8/128", "Global"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if got := network.Scope(); got != tt.want {
+				t.Errorf("Scope() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormattedText_ScopeRow(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		noClass bool
+		want    bool
+	}{
+		{"fe80::1/64", false, true},
+		{"fe80::1/64", true, false},
+		{"::/0", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.Format.NoClass = tt.noClass
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			for _, output := range []string{network.FormattedText(), network.FormattedTextWithBinary()} {
+				if got := strings.Contains(output, "Scope:\t"); got != tt.want {
+					t.Errorf("Scope row shown = %v, want %v:\n%s", got, tt.want, output)
+				}
+			}
+		})
+	}
+}
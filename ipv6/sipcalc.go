@@ -0,0 +1,84 @@
+package ipv6
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// SipcalcText returns the network in the layout of sipcalc's [IPV6 INFO] section, with the same
+// field labels, address notations and tab alignment, so scripts written against sipcalc can read
+// ripcalc's output instead
+func (n *Network) SipcalcText() string {
+	first, last := n.bounds()
+	mask := calculateIPv6Netmask(n.PrefixLength)
+	hostID := make(net.IP, 16)
+
+	for i, b := range n.Address.To16() {
+		hostID[i] = b &^ mask[i]
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "-[ipv6 : %s/%d] - 0\n\n", FormatHextets(n.Address), n.PrefixLength)
+	b.WriteString("[IPV6 INFO]\n")
+	fmt.Fprintf(&b, "Expanded Address\t- %s\n", expandedAddress(n.Address))
+	fmt.Fprintf(&b, "Compressed address\t- %s\n", FormatHextets(n.Address))
+	fmt.Fprintf(&b, "Subnet prefix (masked)\t- %s/%d\n", uncompressedAddress(fromBigInt(first)), n.PrefixLength)
+	fmt.Fprintf(&b, "Address ID (masked)\t- %s/%d\n", uncompressedAddress(hostID), n.PrefixLength)
+	fmt.Fprintf(&b, "Prefix address\t\t- %s\n", uncompressedAddress(mask))
+	fmt.Fprintf(&b, "Prefix length\t\t- %d\n", n.PrefixLength)
+	fmt.Fprintf(&b, "Address type\t\t- %s\n", sipcalcAddressType(n.Address))
+	fmt.Fprintf(&b, "Network range\t\t- %s -\n", expandedAddress(fromBigInt(first)))
+	fmt.Fprintf(&b, "\t\t\t  %s\n\n", expandedAddress(fromBigInt(last)))
+	b.WriteString("-")
+
+	return b.String()
+}
+
+// expandedAddress renders all eight hextets zero-padded to four digits, e.g.
+// 2001:0db8:0000:0000:0000:0000:0000:0001
+func expandedAddress(ip net.IP) string {
+	parts := make([]string, 8)
+	for i, g := range hextets(ip.To16(), 8) {
+		parts[i] = fmt.Sprintf("%04x", g)
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// uncompressedAddress renders all eight hextets without zero padding or "::", e.g.
+// 2001:db8:0:0:0:0:0:1
+func uncompressedAddress(ip net.IP) string {
+	parts := make([]string, 8)
+	for i, g := range hextets(ip.To16(), 8) {
+		parts[i] = fmt.Sprintf("%x", g)
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// sipcalcAddressType names the address's type the way sipcalc does, which follows the RFC 2373
+// address type prefixes rather than the current special-purpose registry
+func sipcalcAddressType(ip net.IP) string {
+	ip = ip.To16()
+
+	switch {
+	case ip.Equal(net.IPv6unspecified):
+		return "Unspecified"
+	case ip.Equal(net.IPv6loopback):
+		return "Loopback"
+	case ip[0]&0xe0 == 0x20:
+		return "Aggregatable Global Unicast Addresses"
+	case ip[0] == 0xfe && ip[1]&0xc0 == 0x80:
+		return "Link-Local Unicast Addresses"
+	case ip[0] == 0xfe && ip[1]&0xc0 == 0xc0:
+		return "Site-Local Unicast Addresses"
+	case ip[0] == 0xff:
+		return "Multicast Addresses"
+	case ip.To4() != nil:
+		return "IPv4-mapped IPv6 address"
+	default:
+		return "Reserved"
+	}
+}
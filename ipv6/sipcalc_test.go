@@ -0,0 +1,38 @@
+package ipv6_test
+
+import (
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestSipcalcText(t *testing.T) {
+	// Expected output of `sipcalc 2001:db8::1/64`
+	want := "-[ipv6 : 2001:db8::1/64] - 0\n" +
+		"\n" +
+		"[IPV6 INFO]\n" +
+		"Expanded Address\t- 2001:0db8:0000:0000:0000:0000:0000:0001\n" +
+		"Compressed address\t- 2001:db8::1\n" +
+		"Subnet prefix (masked)\t- 2001:db8:0:0:0:0:0:0/64\n" +
+		"Address ID (masked)\t- 0:0:0:0:0:0:0:1/64\n" +
+		"Prefix address\t\t- ffff:ffff:ffff:ffff:0:0:0:0\n" +
+		"Prefix length\t\t- 64\n" +
+		"Address type\t\t- Aggregatable Global Unicast Addresses\n" +
+		"Network range\t\t- 2001:0db8:0000:0000:0000:0000:0000:0000 -\n" +
+		"\t\t\t  2001:0db8:0000:0000:ffff:ffff:ffff:ffff\n" +
+		"\n" +
+		"-"
+
+	network, err := ipv6.ParseCIDR("2001:db8::1/64")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if got := network.SipcalcText(); got != want {
+		t.Errorf("SipcalcText() =\n%s\nwant\n%s", got, want)
+	}
+}
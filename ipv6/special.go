@@ -0,0 +1,16 @@
+package ipv6
+
+import "net"
+
+// IsDocumentation reports whether ip is in one of the prefixes reserved for documentation and
+// examples, 2001:db8::/32 (RFC 3849) and 3fff::/20 (RFC 9637)
+func IsDocumentation(ip net.IP) bool {
+	return ip.To4() == nil && ip.To16() != nil && classifyAddressType(ip) == addressTypeDocumentation
+}
+
+// IsGloballyRoutable reports whether ip would be routed on the public internet: only global
+// unicast (2000::/3) outside the more specific special-purpose prefixes such as documentation,
+// 6to4, Teredo, ORCHID and benchmarking qualifies
+func IsGloballyRoutable(ip net.IP) bool {
+	return ip.To4() == nil && ip.To16() != nil && classifyAddressType(ip) == addressTypeGlobalUnicast
+}
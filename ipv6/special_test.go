@@ -0,0 +1,127 @@
+package ipv6_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestIsDocumentation(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"2001:db8::1", true},
+		{"2001:db8:ffff::", true},
+		{"3fff:fff::1", true},
+		{"3fff:1000::", false},
+		{"2001:db9::1", false},
+		{"192.0.2.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ipv6.IsDocumentation(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsDocumentation(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculate_SpecialPurposeClasses(t *testing.T) {
+	tests := []struct {
+		cidr      string
+		wantClass string
+		wantType  string
+	}{
+		{"100::1/128", "Discard", "Discard Only"},
+		{"100::/64", "Discard", "Discard Only"},
+		{"100:0:0:1::/64", "Reserved", "Reserved"},
+		{"2001:2::1/128", "Benchmarking", "Benchmark Testing"},
+		{"2001:10::1/128", "ORCHID (Deprecated)", "Cryptographic Identifier"},
+		{"2001:20::1/128", "ORCHIDv2", "Cryptographic Identifier"},
+		{"2001:30::1/128", "Global Unicast", "Internet Routable"},
+		{"2001::1/128", "Teredo", "NAT Traversal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if network.Class != tt.wantClass || network.Type != tt.wantType {
+				t.Errorf("Class, Type = %q, %q, want %q, %q", network.Class, network.Type, tt.wantClass, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestCalculate_RIR(t *testing.T) {
+	tests := []struct {
+		cidr     string
+		rir      bool
+		wantType string
+	}{
+		{"2a00::1/128", true, "Internet Routable (RIPE NCC)"},
+		{"2600::1/128", true, "Internet Routable (ARIN)"},
+		{"2400:cb00::1/128", true, "Internet Routable (APNIC)"},
+		{"2001:4860::1/128", true, "Internet Routable (IANA)"},
+		{"3000::1/128", true, "Internet Routable"},
+		{"2001:2::1/128", true, "Benchmark Testing"},
+		{"2a00::1/128", false, "Internet Routable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			network.Format.RIR = tt.rir
+
+			if err := network.Calculate(); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if network.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", network.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestIsGloballyRoutable(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"2001:4860::8888", true},
+		{"2a00:1450::1", true},
+		{"2001:db8::1", false},
+		{"3fff::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"ff02::1", false},
+		{"::1", false},
+		{"::", false},
+		{"::ffff:8.8.8.8", false},
+		{"2002:c000:204::1", false},
+		{"8.8.8.8", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := ipv6.IsGloballyRoutable(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("IsGloballyRoutable(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
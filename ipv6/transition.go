@@ -0,0 +1,45 @@
+package ipv6
+
+import "net"
+
+// transitionPrefix is an IPv6 transition mechanism identified by its address prefix
+type transitionPrefix struct {
+	network    *net.IPNet
+	name       string
+	deprecated bool
+}
+
+var transitionPrefixes = []transitionPrefix{
+	{mustParseCIDR("2002::/16"), "6to4", true},
+	{mustParseCIDR("2001::/32"), "Teredo", false},
+	{mustParseCIDR("64:ff9b::/96"), "NAT64", false},
+	{mustParseCIDR("64:ff9b:1::/48"), "NAT64", false},
+}
+
+// TransitionMechanism returns the IPv4 transition mechanism the address belongs to and whether
+// that mechanism is deprecated. 6to4, Teredo and NAT64 are recognised by prefix, ISATAP by the
+// 0000:5efe (or 0200:5efe) interface identifier. ok is false for native addresses.
+func (n *Network) TransitionMechanism() (name string, deprecated bool, ok bool) {
+	for _, tp := range transitionPrefixes {
+		if tp.network.Contains(n.Address) {
+			return tp.name, tp.deprecated, true
+		}
+	}
+
+	if isISATAP(n.Address) {
+		return "ISATAP", false, true
+	}
+
+	return "", false, false
+}
+
+// isISATAP reports whether the interface identifier follows the ISATAP ::5efe:a.b.c.d pattern,
+// allowing the universal/local bit to be set
+func isISATAP(ip net.IP) bool {
+	ip = ip.To16()
+	if ip == nil {
+		return false
+	}
+
+	return ip[8]&^0x02 == 0 && ip[9] == 0 && ip[10] == 0x5e && ip[11] == 0xfe
+}
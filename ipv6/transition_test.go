@@ -0,0 +1,57 @@
+package ipv6_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestNetwork_TransitionMechanism(t *testing.T) {
+	tests := []struct {
+		name           string
+		cidr           string
+		wantName       string
+		wantDeprecated bool
+		wantOK         bool
+	}{
+		{"6to4", "2002:c000:201::/48", "6to4", true, true},
+		{"Teredo", "2001:0:4136:e378:8000:63bf:3fff:fdd2/128", "Teredo", false, true},
+		{"NAT64 well-known", "64:ff9b::c000:201/128", "NAT64", false, true},
+		{"NAT64 local-use", "64:ff9b:1::/48", "NAT64", false, true},
+		{"ISATAP link-local", "fe80::5efe:c000:201/128", "ISATAP", false, true},
+		{"ISATAP global with u/l bit", "2001:db8::200:5efe:c000:201/128", "ISATAP", false, true},
+		{"native", "2001:db8::1/128", "", false, false},
+		{"documentation network", "2001:db8::/32", "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network, err := ipv6.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("ParseCIDR() error = %v", err)
+			}
+
+			name, deprecated, ok := network.TransitionMechanism()
+			if name != tt.wantName || deprecated != tt.wantDeprecated || ok != tt.wantOK {
+				t.Errorf("TransitionMechanism() = %q, %v, %v, want %q, %v, %v",
+					name, deprecated, ok, tt.wantName, tt.wantDeprecated, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFormattedText_TransitionNote(t *testing.T) {
+	network, err := ipv6.ParseCIDR("2002::/16")
+	if err != nil {
+		t.Fatalf("ParseCIDR() error = %v", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if output := network.FormattedText(); !strings.Contains(output, "6to4 transition address (deprecated)") {
+		t.Errorf("FormattedText() missing transition note:\n%s", output)
+	}
+}
@@ -0,0 +1,76 @@
+// Package ripcalc describes IPv4 and IPv6 networks through a single entry point, for callers that
+// handle both families alike. The ipv4 and ipv6 packages hold the family-specific calculations.
+package ripcalc
+
+import (
+	"fmt"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// NetworkInfo is the view of a calculated network common to both families. It's implemented by
+// *ipv4.Network and *ipv6.Network.
+type NetworkInfo interface {
+	// String returns the address and prefix length as given, host bits included
+	String() string
+	// CanonicalCIDR returns the network address and prefix length with the host bits cleared
+	CanonicalCIDR() string
+	// HostCountString returns the host count as a decimal string
+	HostCountString() string
+	// ClassType returns the class and address type summary
+	ClassType() string
+	// FormattedText returns the labelled text output
+	FormattedText() string
+}
+
+var (
+	_ NetworkInfo = (*ipv4.Network)(nil)
+	_ NetworkInfo = (*ipv6.Network)(nil)
+)
+
+// Describe parses cidr in the family DetectFamily finds for it and returns the calculated network.
+// It returns ErrInvalidAddress if cidr is valid for neither family.
+func Describe(cidr string) (NetworkInfo, error) {
+	family, ok := DetectFamily(cidr)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not an IPv4 or IPv6 CIDR", ErrInvalidAddress, cidr)
+	}
+
+	if family == "ipv6" {
+		network, err := ipv6.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("ipv6.ParseCIDR: %w", err)
+		}
+
+		if err := network.Calculate(); err != nil {
+			return nil, fmt.Errorf("network.Calculate: %w", err)
+		}
+
+		return network, nil
+	}
+
+	network, err := ipv4.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("ipv4.ParseCIDR: %w", err)
+	}
+
+	if err := network.Calculate(); err != nil {
+		return nil, fmt.Errorf("network.Calculate: %w", err)
+	}
+
+	return network, nil
+}
+
+// DetectFamily returns "ipv4" or "ipv6" for a valid CIDR of that family, the names --family takes,
+// and false if cidr is valid for neither
+func DetectFamily(cidr string) (string, bool) {
+	switch {
+	case ipv4.IsValidCIDR(cidr):
+		return "ipv4", true
+	case ipv6.IsValidCIDR(cidr):
+		return "ipv6", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,171 @@
+package ripcalc_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ronny/ripcalc"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		cidr          string
+		wantString    string
+		wantCanonical string
+		wantHostCount string
+		wantClassType string
+		wantText      string
+	}{
+		{
+			cidr:          "192.168.1.77/24",
+			wantString:    "192.168.1.77/24",
+			wantCanonical: "192.168.1.0/24",
+			wantHostCount: "254",
+			wantClassType: "Class C, Private Internet",
+			wantText:      "Broadcast:\t192.168.1.255",
+		},
+		{
+			cidr:          "fe80::1/64",
+			wantString:    "fe80::1/64",
+			wantCanonical: "fe80::/64",
+			wantHostCount: "18446744073709551616",
+			wantClassType: "Link-Local Unicast, Auto-configured",
+			wantText:      "Last host:\tfe80::ffff:ffff:ffff:ffff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			info, err := ripcalc.Describe(tt.cidr)
+			if err != nil {
+				t.Fatalf("Describe() error = %v", err)
+			}
+
+			if got := info.String(); got != tt.wantString {
+				t.Errorf("String() = %q, want %q", got, tt.wantString)
+			}
+
+			if got := info.CanonicalCIDR(); got != tt.wantCanonical {
+				t.Errorf("CanonicalCIDR() = %q, want %q", got, tt.wantCanonical)
+			}
+
+			if got := info.HostCountString(); got != tt.wantHostCount {
+				t.Errorf("HostCountString() = %q, want %q", got, tt.wantHostCount)
+			}
+
+			if got := info.ClassType(); got != tt.wantClassType {
+				t.Errorf("ClassType() = %q, want %q", got, tt.wantClassType)
+			}
+
+			if got := info.FormattedText(); !strings.Contains(got, tt.wantText) {
+				t.Errorf("FormattedText() missing %q:\n%s", tt.wantText, got)
+			}
+		})
+	}
+}
+
+func TestDescribe_Invalid(t *testing.T) {
+	for _, cidr := range []string{"10.0.0.0/33", "300.0.0.0/8", "10.0.0.0", "2001:db8::g/64", "2001:db8::/129"} {
+		t.Run(cidr, func(t *testing.T) {
+			if _, err := ripcalc.Describe(cidr); !errors.Is(err, ripcalc.ErrInvalidAddress) {
+				t.Errorf("Describe() error = %v, want ErrInvalidAddress", err)
+			}
+		})
+	}
+}
+
+func TestDescribe_Family(t *testing.T) {
+	info, err := ripcalc.Describe("2001:db8::/32")
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+
+	if _, ok := info.(*ipv6.Network); !ok {
+		t.Errorf("Describe() = %T, want *ipv6.Network", info)
+	}
+}
+
+func TestDetectFamily(t *testing.T) {
+	tests := []struct {
+		cidr   string
+		want   string
+		wantOK bool
+	}{
+		{"192.168.0.0/24", "ipv4", true},
+		{"10.0.0.0/255.0.0.0", "ipv4", true},
+		{"2001:db8::/32", "ipv6", true},
+		{"fe80::1%eth0/64", "ipv6", true},
+		{"10.0.0.0", "", false},
+		{"10.0.0.0/33", "", false},
+		{"2001:db8::/129", "", false},
+		{"not a cidr", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			got, ok := ripcalc.DetectFamily(tt.cidr)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("DetectFamily() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewTemplateData(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want ripcalc.TemplateData
+	}{
+		{"192.168.1.77/24", ripcalc.TemplateData{
+			Family:         "ipv4",
+			Address:        "192.168.1.77",
+			Prefix:         24,
+			CIDR:           "192.168.1.0/24",
+			Network:        "192.168.1.0",
+			Netmask:        "255.255.255.0",
+			Wildcard:       "0.0.0.255",
+			FirstHost:      "192.168.1.1",
+			LastHost:       "192.168.1.254",
+			Broadcast:      "192.168.1.255",
+			HostCount:      "254",
+			TotalAddresses: "256",
+			ClassType:      "Class C, Private Internet",
+			Scope:          "Private",
+		}},
+		{"2001:db8::1/64", ripcalc.TemplateData{
+			Family:         "ipv6",
+			Address:        "2001:db8::1",
+			Prefix:         64,
+			CIDR:           "2001:db8::/64",
+			Network:        "2001:db8::",
+			Netmask:        "ffff:ffff:ffff:ffff::",
+			Wildcard:       "::ffff:ffff:ffff:ffff",
+			FirstHost:      "2001:db8::",
+			LastHost:       "2001:db8::ffff:ffff:ffff:ffff",
+			HostCount:      "18446744073709551616",
+			TotalAddresses: "18446744073709551616",
+			ClassType:      "Documentation, RFC Example",
+			Scope:          "Global",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			info, err := ripcalc.Describe(tt.cidr)
+			if err != nil {
+				t.Fatalf("Describe() error = %v", err)
+			}
+
+			got, err := ripcalc.NewTemplateData(info)
+			if err != nil {
+				t.Fatalf("NewTemplateData() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("NewTemplateData() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package ripcalc
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/ronny/ripcalc/ipv4"
+	"github.com/ronny/ripcalc/ipv6"
+)
+
+// TemplateData holds a calculated network's fields as strings for rendering with text/template,
+// e.g. "{{.Network}}/{{.Prefix}} has {{.HostCount}} hosts". The field names are the same for both
+// families and won't change; fields a family doesn't have, like an IPv6 Broadcast, are empty.
+type TemplateData struct {
+	// Family is "ipv4" or "ipv6"
+	Family string
+	// Address is the address as given, host bits included
+	Address string
+	// Prefix is the prefix length
+	Prefix int
+	// CIDR is the network address and prefix length, e.g. "192.168.1.0/24"
+	CIDR string
+	// Network is the network address without the prefix length
+	Network   string
+	Netmask   string
+	Wildcard  string
+	FirstHost string
+	LastHost  string
+	// Broadcast is the IPv4 broadcast address, and empty for IPv6
+	Broadcast string
+	// HostCount is the number of usable hosts, in decimal
+	HostCount string
+	// TotalAddresses is the number of addresses in the network, in decimal
+	TotalAddresses string
+	// ClassType is the class and address type summary, empty with the NoClass format option
+	ClassType string
+	// Scope is the address scope, e.g. "Private" or "Global"
+	Scope string
+}
+
+// NewTemplateData returns the template fields of a network calculated by the ipv4 or ipv6 package.
+// It returns ErrInvalidAddress for any other NetworkInfo implementation.
+func NewTemplateData(info NetworkInfo) (TemplateData, error) {
+	switch n := info.(type) {
+	case *ipv4.Network:
+		return TemplateData{
+			Family:         "ipv4",
+			Address:        n.Address.String(),
+			Prefix:         n.PrefixLength,
+			CIDR:           n.CanonicalCIDR(),
+			Network:        n.Network.String(),
+			Netmask:        net.IP(n.Netmask).String(),
+			Wildcard:       n.Wildcard.String(),
+			FirstHost:      n.FirstUsable().String(),
+			LastHost:       n.LastUsable().String(),
+			Broadcast:      n.Broadcast.String(),
+			HostCount:      strconv.FormatUint(n.UsableHosts(), 10),
+			TotalAddresses: strconv.FormatUint(n.TotalAddresses(), 10),
+			ClassType:      n.ClassType(),
+			Scope:          n.Scope(),
+		}, nil
+	case *ipv6.Network:
+		return TemplateData{
+			Family:         "ipv6",
+			Address:        ipv6.FormatHextets(n.Address),
+			Prefix:         n.PrefixLength,
+			CIDR:           n.CanonicalCIDR(),
+			Network:        ipv6.FormatHextets(n.Network),
+			Netmask:        ipv6.FormatHextets(n.Netmask),
+			Wildcard:       ipv6.FormatHextets(n.Wildcard),
+			FirstHost:      ipv6.FormatHextets(n.HostMin),
+			LastHost:       ipv6.FormatHextets(n.HostMax),
+			HostCount:      n.HostCountString(),
+			TotalAddresses: n.TotalAddresses().String(),
+			ClassType:      n.ClassType(),
+			Scope:          n.Scope(),
+		}, nil
+	default:
+		return TemplateData{}, fmt.Errorf("%w: unsupported network type %T", ErrInvalidAddress, info)
+	}
+}
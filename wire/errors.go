@@ -0,0 +1,8 @@
+package wire
+
+import "errors"
+
+var (
+	ErrMalformed          = errors.New("malformed message")
+	ErrUnsupportedVersion = errors.New("unsupported wire format version")
+)
@@ -0,0 +1,234 @@
+// Package wire encodes calculated networks as a stream of length-prefixed binary messages, the
+// format written by ripcalc --proto, and decodes them again for consumers of the stream.
+//
+// Every message is a 4-byte big-endian length followed by that many bytes of body:
+//
+//	version      1 byte, currently 1
+//	family       1 byte, 4 or 6
+//	prefix       1 byte, the prefix length
+//	address      4 bytes for IPv4, 16 for IPv6
+//	network      as address
+//	host min     as address
+//	host max     as address
+//	host count   1-byte length, then that many bytes of big-endian unsigned integer
+//	class        uvarint length, then that many bytes of UTF-8
+//	type         uvarint length, then that many bytes of UTF-8
+//
+// Class and type are empty when classification was turned off.
+package wire
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+)
+
+// Version is the wire format version written in every message
+const Version = 1
+
+// maxMessageSize bounds the body length a Reader accepts, well above the largest valid message, so
+// a corrupt length can't force a huge allocation
+const maxMessageSize = 64 << 10
+
+// Record is one calculated network as carried on the wire
+type Record struct {
+	// Family is 4 or 6
+	Family       int
+	Address      net.IP
+	PrefixLength int
+	Network      net.IP
+	HostMin      net.IP
+	HostMax      net.IP
+	HostCount    *big.Int
+	Class        string
+	Type         string
+}
+
+// addressSize returns the encoded size of each address for the record's family
+func (r Record) addressSize() (int, error) {
+	switch r.Family {
+	case 4:
+		return net.IPv4len, nil
+	case 6:
+		return net.IPv6len, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown family %d", ErrMalformed, r.Family)
+	}
+}
+
+// MarshalBinary encodes the record as a message body, without the length prefix
+func (r Record) MarshalBinary() ([]byte, error) {
+	size, err := r.addressSize()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.PrefixLength < 0 || r.PrefixLength > size*8 {
+		return nil, fmt.Errorf("%w: prefix /%d is outside /0 to /%d", ErrMalformed, r.PrefixLength, size*8)
+	}
+
+	count := r.HostCount
+	if count == nil {
+		count = new(big.Int)
+	}
+
+	if count.Sign() < 0 {
+		return nil, fmt.Errorf("%w: negative host count %s", ErrMalformed, count)
+	}
+
+	data := []byte{Version, byte(r.Family), byte(r.PrefixLength)}
+
+	for _, ip := range []net.IP{r.Address, r.Network, r.HostMin, r.HostMax} {
+		encoded := ip.To16()
+		if size == net.IPv4len {
+			encoded = ip.To4()
+		}
+
+		if encoded == nil {
+			return nil, fmt.Errorf("%w: %v isn't an IPv%d address", ErrMalformed, ip, r.Family)
+		}
+
+		data = append(data, encoded...)
+	}
+
+	countBytes := count.Bytes()
+	data = append(data, byte(len(countBytes)))
+	data = append(data, countBytes...)
+
+	for _, s := range []string{r.Class, r.Type} {
+		data = binary.AppendUvarint(data, uint64(len(s)))
+		data = append(data, s...)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary decodes a message body, without the length prefix, into the record
+func (r *Record) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 {
+		return fmt.Errorf("%w: %d-byte body is too short", ErrMalformed, len(data))
+	}
+
+	if data[0] != Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[0])
+	}
+
+	decoded := Record{Family: int(data[1]), PrefixLength: int(data[2])}
+
+	size, err := decoded.addressSize()
+	if err != nil {
+		return err
+	}
+
+	if decoded.PrefixLength > size*8 {
+		return fmt.Errorf("%w: prefix /%d is longer than /%d", ErrMalformed, decoded.PrefixLength, size*8)
+	}
+
+	data = data[3:]
+	if len(data) < 4*size+1 {
+		return fmt.Errorf("%w: truncated addresses", ErrMalformed)
+	}
+
+	for _, ip := range []*net.IP{&decoded.Address, &decoded.Network, &decoded.HostMin, &decoded.HostMax} {
+		*ip = net.IP(append([]byte(nil), data[:size]...))
+		data = data[size:]
+	}
+
+	countLen := int(data[0])
+	if len(data) < 1+countLen {
+		return fmt.Errorf("%w: truncated host count", ErrMalformed)
+	}
+
+	decoded.HostCount = new(big.Int).SetBytes(data[1 : 1+countLen])
+	data = data[1+countLen:]
+
+	for _, s := range []*string{&decoded.Class, &decoded.Type} {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return fmt.Errorf("%w: truncated string", ErrMalformed)
+		}
+
+		*s = string(data[n : n+int(length)])
+		data = data[n+int(length):]
+	}
+
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrMalformed, len(data))
+	}
+
+	*r = decoded
+
+	return nil
+}
+
+// Writer writes records as length-prefixed messages
+type Writer struct {
+	w io.Writer
+}
+
+// NewWriter returns a Writer that writes messages to w. Wrap w in a bufio.Writer for throughput
+// and flush it when done.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write encodes the record and writes it as one message
+func (w *Writer) Write(r Record) error {
+	body, err := r.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	message := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(body)), uint32(len(body)))
+
+	if _, err := w.w.Write(append(message, body...)); err != nil {
+		return fmt.Errorf("w.Write: %w", err)
+	}
+
+	return nil
+}
+
+// Reader decodes records from a stream of length-prefixed messages
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader returns a Reader that decodes messages from r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record. It returns io.EOF once the stream ends cleanly between messages
+// and ErrMalformed if it ends part way through one.
+func (r *Reader) Read() (Record, error) {
+	var prefix [4]byte
+
+	if _, err := io.ReadFull(r.r, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+
+		return Record{}, fmt.Errorf("%w: truncated length prefix: %w", ErrMalformed, err)
+	}
+
+	length := binary.BigEndian.Uint32(prefix[:])
+	if length > maxMessageSize {
+		return Record{}, fmt.Errorf("%w: %d-byte message exceeds %d bytes", ErrMalformed, length, maxMessageSize)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r.r, body); err != nil {
+		return Record{}, fmt.Errorf("%w: truncated body: %w", ErrMalformed, err)
+	}
+
+	var record Record
+	if err := record.UnmarshalBinary(body); err != nil {
+		return Record{}, err
+	}
+
+	return record, nil
+}
@@ -0,0 +1,122 @@
+package wire_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ronny/ripcalc/wire"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ipv6Count, _ := new(big.Int).SetString("18446744073709551616", 10)
+
+	records := []wire.Record{
+		{
+			Family:       4,
+			Address:      net.ParseIP("192.168.0.1").To4(),
+			PrefixLength: 24,
+			Network:      net.ParseIP("192.168.0.0").To4(),
+			HostMin:      net.ParseIP("192.168.0.1").To4(),
+			HostMax:      net.ParseIP("192.168.0.254").To4(),
+			HostCount:    big.NewInt(254),
+			Class:        "C",
+			Type:         "Private Internet",
+		},
+		{
+			Family:       6,
+			Address:      net.ParseIP("2001:db8::1"),
+			PrefixLength: 64,
+			Network:      net.ParseIP("2001:db8::"),
+			HostMin:      net.ParseIP("2001:db8::"),
+			HostMax:      net.ParseIP("2001:db8::ffff:ffff:ffff:ffff"),
+			HostCount:    ipv6Count,
+			Class:        "Documentation",
+			Type:         "RFC Example",
+		},
+		{
+			Family:       4,
+			Address:      net.ParseIP("10.0.0.1").To4(),
+			PrefixLength: 32,
+			Network:      net.ParseIP("10.0.0.1").To4(),
+			HostMin:      net.ParseIP("10.0.0.1").To4(),
+			HostMax:      net.ParseIP("10.0.0.1").To4(),
+			HostCount:    new(big.Int),
+		},
+	}
+
+	var stream bytes.Buffer
+
+	writer := wire.NewWriter(&stream)
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	reader := wire.NewReader(&stream)
+	for i, want := range records {
+		got, err := reader.Read()
+		if err != nil {
+			t.Fatalf("Read() record %d error = %v", i, err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Read() record %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	if _, err := reader.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Read() at end error = %v, want io.EOF", err)
+	}
+}
+
+func TestReadMalformed(t *testing.T) {
+	var valid bytes.Buffer
+
+	err := wire.NewWriter(&valid).Write(wire.Record{
+		Family:       4,
+		Address:      net.IPv4(10, 0, 0, 0),
+		PrefixLength: 8,
+		Network:      net.IPv4(10, 0, 0, 0),
+		HostMin:      net.IPv4(10, 0, 0, 1),
+		HostMax:      net.IPv4(10, 255, 255, 254),
+		HostCount:    big.NewInt(16777214),
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	badVersion := bytes.Clone(valid.Bytes())
+	badVersion[4] = 99
+
+	tests := []struct {
+		name      string
+		stream    []byte
+		wantError error
+	}{
+		{"truncated prefix", valid.Bytes()[:2], wire.ErrMalformed},
+		{"truncated body", valid.Bytes()[:valid.Len()-1], wire.ErrMalformed},
+		{"oversized length", []byte{0xff, 0xff, 0xff, 0xff}, wire.ErrMalformed},
+		{"unknown version", badVersion, wire.ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := wire.NewReader(bytes.NewReader(tt.stream)).Read(); !errors.Is(err, tt.wantError) {
+				t.Errorf("Read() error = %v, want %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestMarshalBinaryRejectsWrongFamily(t *testing.T) {
+	_, err := wire.Record{Family: 4, Address: net.ParseIP("2001:db8::1"), PrefixLength: 24}.MarshalBinary()
+	if !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("MarshalBinary() error = %v, want ErrMalformed", err)
+	}
+}